@@ -0,0 +1,103 @@
+package age
+
+import (
+	"time"
+)
+
+// Convention 나이 계산 방식
+type Convention string
+
+// 지원가능한 나이 계산 방식 값
+const (
+	International Convention = "international" // 만 나이(생일이 지날 때마다 한 살씩 더한다)
+	Korean        Convention = "korean"        // 세는 나이(태어난 해를 한 살로 하여 해가 바뀔 때마다 한 살씩 더한다)
+	Year          Convention = "year"          // 연 나이(기준일의 연도에서 태어난 연도를 뺀다)
+)
+
+// Conventions 지원가능한 나이 계산 방식 목록
+var Conventions = []Convention{International, Korean, Year}
+
+func (c Convention) String() string {
+	switch c {
+	case International:
+		return "만 나이"
+	case Korean:
+		return "세는 나이"
+	case Year:
+		return "연 나이"
+	default:
+		return string(c)
+	}
+}
+
+// ParseConvention 나이 계산 방식 문자열을 나이 계산 방식으로 변환한다. 지원하지 않는 나이 계산 방식이면 false를 반환한다.
+func ParseConvention(s string) (Convention, bool) {
+	for _, c := range Conventions {
+		if string(c) == s {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// Of 기준일(at) 현재 생년월일(birthDate)에 해당하는 나이를 나이 계산 방식에 따라 계산한다.
+func (c Convention) Of(birthDate time.Time, at time.Time) int {
+	switch c {
+	case International:
+		age := at.Year() - birthDate.Year()
+		if at.Month() < birthDate.Month() || (at.Month() == birthDate.Month() && at.Day() < birthDate.Day()) {
+			age--
+		}
+		return age
+	case Year:
+		return at.Year() - birthDate.Year()
+	default:
+		return at.Year() - birthDate.Year() + 1
+	}
+}
+
+// YearRange 기준일(at) 현재 birthYear년생의 나이 범위를 나이 계산 방식에 따라 계산한다.
+// 만 나이는 생일이 지났는지에 따라 나이가 달라지므로 두 나이를 모두 포함하는 범위를 반환한다.
+func (c Convention) YearRange(birthYear int, at time.Time) (int, int) {
+	switch c {
+	case International:
+		return at.Year() - birthYear - 1, at.Year() - birthYear
+	case Year:
+		return at.Year() - birthYear, at.Year() - birthYear
+	default:
+		return at.Year() - birthYear + 1, at.Year() - birthYear + 1
+	}
+}
+
+// GradeRange 기준일(at) 현재 초등학교 grade학년 학생의 나이 범위를 나이 계산 방식에 따라 계산한다.
+// 학년은 3월에 시작하므로 1~2월은 전년도 학년으로 계산한다.
+func (c Convention) GradeRange(grade int, at time.Time) (int, int) {
+	schoolYear := at.Year()
+	if at.Month() < time.March {
+		schoolYear--
+	}
+
+	// 초등학교 1학년은 입학하는 해에 연 나이로 7살이 되는 아이다.
+	birthYear := schoolYear - grade - 6
+
+	return c.YearRange(birthYear, at)
+}
+
+// Months 기준일(at) 현재 생년월일(birthDate)에 해당하는 개월수를 계산한다.
+// 기준일의 일자가 생일의 일자보다 작으면 한 달이 지나지 않은 것으로 하며, 생일의 일자가 기준월에 없으면 기준월의 말일을 생일로 한다(예:1월 31일생은 2월 28일에 1개월).
+func Months(birthDate time.Time, at time.Time) int {
+	months := (at.Year()-birthDate.Year())*12 + int(at.Month()) - int(birthDate.Month())
+
+	day := birthDate.Day()
+	if lastDay := time.Date(at.Year(), at.Month()+1, 0, 0, 0, 0, 0, at.Location()).Day(); day > lastDay {
+		day = lastDay
+	}
+	if at.Day() < day {
+		months--
+	}
+
+	if months < 0 {
+		return 0
+	}
+	return months
+}
@@ -0,0 +1,114 @@
+package age
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestOf(t *testing.T) {
+	birthDate := date(2016, time.March, 18)
+
+	tests := []struct {
+		convention Convention
+		at         time.Time
+		want       int
+	}{
+		// 생일 전날
+		{International, date(2025, time.March, 17), 8},
+		{Korean, date(2025, time.March, 17), 10},
+		{Year, date(2025, time.March, 17), 9},
+
+		// 생일 당일
+		{International, date(2025, time.March, 18), 9},
+		{Korean, date(2025, time.March, 18), 10},
+		{Year, date(2025, time.March, 18), 9},
+
+		// 해가 바뀌기 전날
+		{International, date(2024, time.December, 31), 8},
+		{Korean, date(2024, time.December, 31), 9},
+		{Year, date(2024, time.December, 31), 8},
+	}
+	for _, tt := range tests {
+		if got := tt.convention.Of(birthDate, tt.at); got != tt.want {
+			t.Errorf("%s.Of(%s, %s) = %d, want %d", tt.convention, birthDate.Format("2006-01-02"), tt.at.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestYearRange(t *testing.T) {
+	tests := []struct {
+		convention Convention
+		at         time.Time
+		from, to   int
+	}{
+		{International, date(2025, time.January, 15), 8, 9},
+		{Korean, date(2025, time.January, 15), 10, 10},
+		{Year, date(2025, time.January, 15), 9, 9},
+		{International, date(2025, time.March, 2), 8, 9},
+		{Korean, date(2025, time.March, 2), 10, 10},
+		{Year, date(2025, time.March, 2), 9, 9},
+	}
+	for _, tt := range tests {
+		if from, to := tt.convention.YearRange(2016, tt.at); from != tt.from || to != tt.to {
+			t.Errorf("%s.YearRange(2016, %s) = (%d, %d), want (%d, %d)", tt.convention, tt.at.Format("2006-01-02"), from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestGradeRange(t *testing.T) {
+	tests := []struct {
+		convention Convention
+		grade      int
+		at         time.Time
+		from, to   int
+	}{
+		// 1~2월은 전년도 학년이므로 2025년 2월의 1학년은 2017년생이다.
+		{International, 1, date(2025, time.January, 15), 7, 8},
+		{Korean, 1, date(2025, time.February, 28), 9, 9},
+		{Year, 1, date(2025, time.February, 28), 8, 8},
+
+		// 3월부터는 새 학년이므로 2025년 3월의 1학년은 2018년생이다.
+		{International, 1, date(2025, time.March, 2), 6, 7},
+		{Korean, 1, date(2025, time.March, 2), 8, 8},
+		{Year, 1, date(2025, time.March, 2), 7, 7},
+
+		{Korean, 6, date(2025, time.February, 28), 14, 14},
+		{Korean, 6, date(2025, time.March, 2), 13, 13},
+	}
+	for _, tt := range tests {
+		if from, to := tt.convention.GradeRange(tt.grade, tt.at); from != tt.from || to != tt.to {
+			t.Errorf("%s.GradeRange(%d, %s) = (%d, %d), want (%d, %d)", tt.convention, tt.grade, tt.at.Format("2006-01-02"), from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestMonths(t *testing.T) {
+	tests := []struct {
+		birthDate time.Time
+		at        time.Time
+		want      int
+	}{
+		{date(2016, time.March, 18), date(2025, time.March, 17), 107},
+		{date(2016, time.March, 18), date(2025, time.March, 18), 108},
+
+		// 생일의 일자가 기준월에 없으면 기준월의 말일을 생일로 한다.
+		{date(2025, time.January, 31), date(2025, time.February, 27), 0},
+		{date(2025, time.January, 31), date(2025, time.February, 28), 1},
+		{date(2024, time.January, 31), date(2024, time.February, 28), 0},
+		{date(2024, time.January, 31), date(2024, time.February, 29), 1},
+		{date(2025, time.January, 31), date(2025, time.April, 30), 3},
+
+		// 기준일 이후에 태어났으면 0개월이다.
+		{date(2025, time.May, 1), date(2025, time.March, 1), 0},
+		{date(2025, time.March, 20), date(2025, time.March, 1), 0},
+	}
+	for _, tt := range tests {
+		if got := Months(tt.birthDate, tt.at); got != tt.want {
+			t.Errorf("Months(%s, %s) = %d, want %d", tt.birthDate.Format("2006-01-02"), tt.at.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"github.com/darkkaiser/culturelecture-scrape/analyze"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// analyzeCommand 스냅샷 저장소에 저장된 모든 시즌의 수집 결과를 분석하여 Markdown 또는 HTML 형식의 보고서로 저장한다.
+func analyzeCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	output := fs.String("o", "culturelecture-scrape-analysis.html", "보고서 파일(확장자가 .md이면 Markdown, 그 외에는 HTML 형식으로 저장된다)")
+	where := fs.String("where", "", "분석할 강좌가 만족해야 하는 조건식(예:chain == emart && category == dance)")
+	utils.CheckErr(fs.Parse(args))
+
+	whereExpr := parseWhere(*where)
+
+	if cfg.Snapshot.Enabled == false {
+		log.Fatalf("스냅샷 저장소를 사용하지 않도록 설정되어 있습니다(분석할 수집 결과가 없습니다)")
+	}
+
+	store, err := snapshot.Open(cfg.Snapshot.Path)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer store.Close()
+
+	runs, err := store.Runs()
+	utils.CheckErr(err)
+	if len(runs) == 0 {
+		log.Fatalf("스냅샷 저장소(%s)에 저장된 실행이 존재하지 않습니다", cfg.Snapshot.Path)
+	}
+
+	var a *analyze.Analyzer
+	if whereExpr != nil {
+		a = analyze.New(whereExpr.Match)
+	} else {
+		a = analyze.New(nil)
+	}
+
+	// 실행별로 강좌 목록을 읽어들여 분석기에 추가한다.
+	// 분석기는 시즌별로 수집된 모든 강좌의 상태를 보관하므로 사용하는 메모리는 전체 이력의 강좌 갯수에 비례하지만, 강좌마다 집계에 필요한 항목만 보관한다.
+	for _, run := range runs {
+		lectureList, err := store.Lectures(run.ID)
+		utils.CheckErr(err)
+
+		// 활동 분류가 추가되기 전에 저장된 강좌는 분석할 때 분류한다.
+		s := scrape.New(cfg)
+		s.SetLectures(lectureList)
+		s.Classify()
+
+		a.Add(run, s.Lectures())
+	}
+	report := a.Report()
+
+	f, err := os.Create(*output)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	if strings.ToLower(filepath.Ext(*output)) == ".md" {
+		// Markdown 형식은 차트를 보고서 파일과 같은 위치에 SVG 파일로 따로 저장한다.
+		base := strings.TrimSuffix(*output, filepath.Ext(*output))
+		chartPath := func(c analyze.Chart) string {
+			return filepath.Base(base) + "-" + c.Name + ".svg"
+		}
+		for _, c := range report.Charts() {
+			utils.CheckErr(os.WriteFile(filepath.Join(filepath.Dir(*output), chartPath(c)), []byte(c.SVG), 0644))
+		}
+		utils.CheckErr(report.WriteMarkdown(f, chartPath))
+	} else {
+		utils.CheckErr(report.WriteHTML(f))
+	}
+
+	log.Printf("%d개 시즌, %d번의 수집 결과를 분석한 보고서를 저장하였습니다(%s).", len(report.Seasons), report.RunCount, *output)
+}
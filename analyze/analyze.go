@@ -0,0 +1,315 @@
+// Package analyze 스냅샷 저장소에 저장된 여러 시즌의 수집 결과를 분석한다.
+//
+// 시즌별로 점포 및 활동 분류별 강좌 갯수, 평균 수강료, 접수가 시작된 후 마감될 때까지 걸린 시간(감시 명령 등으로 같은 시즌을 여러번 수집한 경우),
+// 여러 시즌에 걸쳐 강좌를 진행한 강사를 집계하여 수강신청 시기와 강좌 구성을 예상하는 데 사용한다.
+package analyze
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// seasonOrder 검색시즌의 순서
+var seasonOrder = map[string]int{"봄": 1, "여름": 2, "가을": 3, "겨울": 4}
+
+// Season 검색년도 및 검색시즌
+type Season struct {
+	Year   string `json:"year"`   // 검색년도
+	Season string `json:"season"` // 검색시즌(봄, 여름, 가을, 겨울)
+}
+
+func (s Season) String() string {
+	return s.Year + "년 " + s.Season
+}
+
+// before 시즌이 다른 시즌보다 앞서는지의 여부를 반환한다.
+func (s Season) before(other Season) bool {
+	if s.Year != other.Year {
+		y1, err1 := strconv.Atoi(s.Year)
+		y2, err2 := strconv.Atoi(other.Year)
+		if err1 == nil && err2 == nil {
+			return y1 < y2
+		}
+		return s.Year < other.Year
+	}
+	o1, o2 := seasonOrder[s.Season], seasonOrder[other.Season]
+	if o1 != o2 {
+		return o1 < o2
+	}
+	return s.Season < other.Season
+}
+
+// SeasonStat 시즌별 집계 결과
+type SeasonStat struct {
+	LectureCount      int     `json:"lectureCount"`      // 강좌 갯수
+	AverageFee        int     `json:"averageFee"`        // 평균 수강료(수강료를 알 수 있는 강좌 기준)
+	AverageSessionFee int     `json:"averageSessionFee"` // 평균 1인 1회당 수강료
+	TrackedCount      int     `json:"trackedCount"`      // 접수가능 상태로 수집되어 마감 여부를 추적한 강좌 갯수
+	ClosedCount       int     `json:"closedCount"`       // 추적한 강좌 중에서 시즌 중에 마감(대기신청 포함)된 강좌 갯수
+	MedianHours       float64 `json:"medianHours"`       // 접수가 시작된 후 마감될 때까지 걸린 시간의 중앙값(시간, 마감된 강좌가 없으면 0)
+
+	fees, sessionFees int
+	feeCount          int
+	sessionFeeCount   int
+	closeHours        []float64
+}
+
+// Row 구분(점포, 활동 분류)별 집계 결과
+type Row struct {
+	Name    string       `json:"name"`    // 구분 값(예:이마트 여수점, 음악)
+	Seasons []SeasonStat `json:"seasons"` // 시즌별 집계 결과(Report.Seasons와 같은 순서)
+}
+
+// Total 모든 시즌의 강좌 갯수를 반환한다.
+func (r *Row) Total() int {
+	total := 0
+	for _, s := range r.Seasons {
+		total += s.LectureCount
+	}
+	return total
+}
+
+// Teacher 여러 시즌에 걸쳐 강좌를 진행한 강사
+type Teacher struct {
+	Name    string   `json:"name"`    // 강사명
+	Stores  []string `json:"stores"`  // 강좌를 진행한 점포 목록
+	Seasons []Season `json:"seasons"` // 강좌를 진행한 시즌 목록(오래된 순서)
+	Titles  []string `json:"titles"`  // 진행한 강좌명 목록
+}
+
+// Report 분석 결과
+type Report struct {
+	Seasons    []Season  `json:"seasons"`    // 분석한 시즌 목록(오래된 순서)
+	RunCount   int       `json:"runCount"`   // 분석한 실행 갯수
+	Total      Row       `json:"total"`      // 전체 집계 결과
+	Stores     []Row     `json:"stores"`     // 점포별 집계 결과
+	Categories []Row     `json:"categories"` // 활동 분류별 집계 결과
+	Teachers   []Teacher `json:"teachers"`   // 여러 시즌에 걸쳐 강좌를 진행한 강사 목록
+}
+
+// tracking 시즌 중에 여러번 수집된 강좌의 상태
+// 시즌마다 모든 강좌의 상태를 보관하므로, 강좌 정보 전체가 아닌 집계에 필요한 항목만 보관한다.
+type tracking struct {
+	chain      string            // 체인 ID
+	storeName  string            // 점포
+	title      string            // 강좌명
+	teacher    string            // 강사명
+	category   lectures.Category // 활동 분류
+	fee        int               // 수강료(알 수 없으면 0)
+	sessionFee int               // 1인 1회당 수강료(알 수 없으면 0)
+
+	openedAt time.Time // 접수가능 상태로 처음 수집된 시각(접수시작일시를 알면 접수시작일시)
+	closedAt time.Time // 접수가능 상태로 수집된 후 마감된 상태로 처음 수집된 시각
+}
+
+// update 가장 최근에 수집된 강좌의 집계 항목으로 변경한다.
+func (t *tracking) update(lecture lectures.Lecture) {
+	lecture.NormalizePrice()
+
+	t.chain, t.storeName, t.title, t.teacher, t.category = lecture.Chain, lecture.StoreName, lecture.Title, strings.TrimSpace(lecture.Teacher), lecture.Category
+	t.fee, t.sessionFee = 0, 0
+	if fee, ok := lecture.PriceValue(); ok == true {
+		t.fee = fee
+	}
+	if sessionFee, ok := lecture.SessionFeeValue(); ok == true {
+		t.sessionFee = sessionFee
+	}
+}
+
+// Analyzer 실행별 수집 결과를 차례대로 추가받아 분석한다.
+type Analyzer struct {
+	match    func(lecture *lectures.Lecture) bool
+	seasons  map[Season]map[string]*tracking
+	runCount int
+}
+
+// New 분석기를 생성한다. match가 nil이 아니면 match를 만족하는 강좌만 분석한다.
+func New(match func(lecture *lectures.Lecture) bool) *Analyzer {
+	return &Analyzer{
+		match:   match,
+		seasons: make(map[Season]map[string]*tracking),
+	}
+}
+
+// Add 실행에서 수집된 강좌 목록을 추가한다. 실행은 수집 시각 순서대로 추가해야 마감까지 걸린 시간을 계산할 수 있다.
+func (a *Analyzer) Add(run snapshot.Run, lectureList []lectures.Lecture) {
+	a.runCount++
+
+	season := Season{Year: run.SearchYear, Season: run.SearchSeason}
+	trackings, exists := a.seasons[season]
+	if exists == false {
+		trackings = make(map[string]*tracking)
+		a.seasons[season] = trackings
+	}
+
+	for _, lecture := range lectureList {
+		if a.match != nil && a.match(&lecture) == false {
+			continue
+		}
+
+		key := lectures.Key(&lecture)
+		t, exists := trackings[key]
+		if exists == false {
+			t = &tracking{}
+			trackings[key] = t
+		}
+		t.update(lecture)
+
+		category := lecture.Status.Category()
+		if t.openedAt.IsZero() == true && lecture.Status.CanRegisterOnline() == true {
+			t.openedAt = run.Time
+			if registerStart, ok := lecture.RegisterStartTime(); ok == true && registerStart.Before(run.Time) == true {
+				t.openedAt = registerStart
+			}
+		}
+		if t.openedAt.IsZero() == false && t.closedAt.IsZero() == true && (category == lectures.ReceptionCategoryClosed || category == lectures.ReceptionCategoryWaitlist) {
+			t.closedAt = run.Time
+		}
+	}
+}
+
+// Report 추가된 수집 결과의 분석 결과를 반환한다.
+func (a *Analyzer) Report() *Report {
+	r := &Report{RunCount: a.runCount, Total: Row{Name: "전체"}}
+
+	for season := range a.seasons {
+		r.Seasons = append(r.Seasons, season)
+	}
+	sort.Slice(r.Seasons, func(i, j int) bool { return r.Seasons[i].before(r.Seasons[j]) })
+
+	stores := make(map[string]*Row)
+	categories := make(map[string]*Row)
+	row := func(rows map[string]*Row, name string) *Row {
+		if rows[name] == nil {
+			rows[name] = &Row{Name: name, Seasons: make([]SeasonStat, len(r.Seasons))}
+		}
+		return rows[name]
+	}
+	r.Total.Seasons = make([]SeasonStat, len(r.Seasons))
+
+	teachers := make(map[string]*Teacher)
+	for i, season := range r.Seasons {
+		for _, t := range a.seasons[season] {
+			for _, stat := range []*SeasonStat{&r.Total.Seasons[i], &row(stores, t.storeName).Seasons[i], &row(categories, t.category.Name()).Seasons[i]} {
+				stat.add(t)
+			}
+
+			if t.teacher != "" {
+				tc, exists := teachers[t.chain+"/"+t.teacher]
+				if exists == false {
+					tc = &Teacher{Name: t.teacher}
+					teachers[t.chain+"/"+t.teacher] = tc
+				}
+				if utils.Contains(tc.Stores, t.storeName) == false {
+					tc.Stores = append(tc.Stores, t.storeName)
+				}
+				if utils.Contains(tc.Titles, t.title) == false {
+					tc.Titles = append(tc.Titles, t.title)
+				}
+				if len(tc.Seasons) == 0 || tc.Seasons[len(tc.Seasons)-1] != season {
+					tc.Seasons = append(tc.Seasons, season)
+				}
+			}
+		}
+	}
+
+	r.Total.finish()
+	r.Stores = sortedRows(stores)
+	r.Categories = sortedRows(categories)
+
+	for _, tc := range teachers {
+		if len(tc.Seasons) > 1 {
+			sort.Strings(tc.Stores)
+			sort.Strings(tc.Titles)
+			r.Teachers = append(r.Teachers, *tc)
+		}
+	}
+	sort.Slice(r.Teachers, func(i, j int) bool {
+		if len(r.Teachers[i].Seasons) != len(r.Teachers[j].Seasons) {
+			return len(r.Teachers[i].Seasons) > len(r.Teachers[j].Seasons)
+		}
+		return r.Teachers[i].Name < r.Teachers[j].Name
+	})
+
+	return r
+}
+
+// add 시즌 중에 수집된 강좌를 집계한다.
+func (s *SeasonStat) add(t *tracking) {
+	s.LectureCount++
+
+	if t.fee > 0 {
+		s.fees += t.fee
+		s.feeCount++
+	}
+	if t.sessionFee > 0 {
+		s.sessionFees += t.sessionFee
+		s.sessionFeeCount++
+	}
+
+	if t.openedAt.IsZero() == false {
+		s.TrackedCount++
+		if t.closedAt.IsZero() == false {
+			s.ClosedCount++
+			s.closeHours = append(s.closeHours, t.closedAt.Sub(t.openedAt).Hours())
+		}
+	}
+}
+
+// finish 집계가 끝난 후 평균 및 중앙값을 계산한다.
+func (r *Row) finish() {
+	for i := range r.Seasons {
+		s := &r.Seasons[i]
+		if s.feeCount > 0 {
+			s.AverageFee = s.fees / s.feeCount
+		}
+		if s.sessionFeeCount > 0 {
+			s.AverageSessionFee = s.sessionFees / s.sessionFeeCount
+		}
+		s.MedianHours = median(s.closeHours)
+	}
+}
+
+// MedianHours 모든 시즌에서 접수가 시작된 후 마감될 때까지 걸린 시간의 중앙값과 마감된 강좌 갯수를 반환한다.
+func (r *Row) MedianHours() (float64, int) {
+	var hours []float64
+	for _, s := range r.Seasons {
+		hours = append(hours, s.closeHours...)
+	}
+	return median(hours), len(hours)
+}
+
+// sortedRows 집계 결과를 강좌 갯수가 많은 순서로 정렬하여 반환한다.
+func sortedRows(rows map[string]*Row) []Row {
+	result := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		row.finish()
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total() != result[j].Total() {
+			return result[i].Total() > result[j].Total()
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return sorted[n/2]
+}
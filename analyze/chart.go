@@ -0,0 +1,150 @@
+package analyze
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"html"
+	"math"
+	"strings"
+)
+
+// 차트 크기 및 여백(픽셀)
+const (
+	chartWidth        = 640
+	chartHeight       = 320
+	chartMarginLeft   = 70
+	chartMarginRight  = 20
+	chartMarginTop    = 40
+	chartMarginBottom = 60
+)
+
+// chartColors 계열별 색상
+var chartColors = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+// Series 선 차트의 계열
+type Series struct {
+	Name   string    // 계열 이름(범례에 표시된다)
+	Values []float64 // 값 목록(레이블과 같은 순서, 값이 없으면 NaN)
+}
+
+// BarChart 레이블별 값을 막대 차트로 그린 SVG 문서를 반환한다.
+func BarChart(title string, labels []string, values []float64, unit string) string {
+	var sb strings.Builder
+	maxValue := chartMax(values)
+	writeChartFrame(&sb, title, labels, maxValue, unit)
+
+	plotWidth := float64(chartWidth - chartMarginLeft - chartMarginRight)
+	step := plotWidth / float64(max(len(labels), 1))
+	barWidth := step * 0.6
+	for i, v := range values {
+		if math.IsNaN(v) == true {
+			continue
+		}
+		x := float64(chartMarginLeft) + step*float64(i) + (step-barWidth)/2
+		y := chartY(v, maxValue)
+		sb.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", x, y, barWidth, float64(chartHeight-chartMarginBottom)-y, chartColors[0]))
+		sb.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="middle" font-size="11">%s</text>`+"\n", x+barWidth/2, y-4, formatValue(v)))
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// LineChart 레이블별 값을 계열별 선 차트로 그린 SVG 문서를 반환한다.
+func LineChart(title string, labels []string, series []Series, unit string) string {
+	var all []float64
+	for _, s := range series {
+		all = append(all, s.Values...)
+	}
+
+	var sb strings.Builder
+	maxValue := chartMax(all)
+	writeChartFrame(&sb, title, labels, maxValue, unit)
+
+	plotWidth := float64(chartWidth - chartMarginLeft - chartMarginRight)
+	step := plotWidth / float64(max(len(labels), 1))
+	for n, s := range series {
+		color := chartColors[n%len(chartColors)]
+
+		var points []string
+		for i, v := range s.Values {
+			if math.IsNaN(v) == true {
+				continue
+			}
+			x := float64(chartMarginLeft) + step*float64(i) + step/2
+			y := chartY(v, maxValue)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+			sb.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="3" fill="%s"/>`+"\n", x, y, color))
+		}
+		if len(points) > 1 {
+			sb.WriteString(fmt.Sprintf(`<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(points, " "), color))
+		}
+
+		// 범례
+		lx := chartMarginLeft + (n%4)*140
+		ly := chartHeight - 22 + (n/4)*14
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="10" height="10" fill="%s"/>`+"\n", lx, ly-9, color))
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="11">%s</text>`+"\n", lx+14, ly, html.EscapeString(s.Name)))
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// writeChartFrame SVG 문서의 시작, 제목, 축 및 눈금을 그린다.
+func writeChartFrame(sb *strings.Builder, title string, labels []string, maxValue float64, unit string) {
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n", chartWidth, chartHeight, chartWidth, chartHeight))
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", chartWidth, chartHeight))
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="24" text-anchor="middle" font-size="15" font-weight="bold">%s</text>`+"\n", chartWidth/2, html.EscapeString(title)))
+
+	bottom := chartHeight - chartMarginBottom
+	for i := 0; i <= 4; i++ {
+		v := maxValue * float64(i) / 4
+		y := chartY(v, maxValue)
+		sb.WriteString(fmt.Sprintf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#e0e0e0"/>`+"\n", chartMarginLeft, y, chartWidth-chartMarginRight, y))
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" font-size="11">%s%s</text>`+"\n", chartMarginLeft-6, y+4, formatValue(v), html.EscapeString(unit)))
+	}
+	sb.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333333"/>`+"\n", chartMarginLeft, bottom, chartWidth-chartMarginRight, bottom))
+
+	plotWidth := float64(chartWidth - chartMarginLeft - chartMarginRight)
+	step := plotWidth / float64(max(len(labels), 1))
+	for i, label := range labels {
+		x := float64(chartMarginLeft) + step*float64(i) + step/2
+		sb.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" text-anchor="middle" font-size="11">%s</text>`+"\n", x, bottom+16, html.EscapeString(label)))
+	}
+}
+
+// chartMax 눈금의 최대값(값 목록의 최대값을 보기 좋게 올림한 값)을 반환한다.
+func chartMax(values []float64) float64 {
+	maxValue := 0.0
+	for _, v := range values {
+		if math.IsNaN(v) == false && v > maxValue {
+			maxValue = v
+		}
+	}
+	if maxValue <= 0 {
+		return 1
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(maxValue)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if maxValue <= m*magnitude {
+			return m * magnitude
+		}
+	}
+	return maxValue
+}
+
+// chartY 값의 y 좌표를 반환한다.
+func chartY(v, maxValue float64) float64 {
+	plotHeight := float64(chartHeight - chartMarginTop - chartMarginBottom)
+	return float64(chartHeight-chartMarginBottom) - plotHeight*v/maxValue
+}
+
+// formatValue 값을 차트에 표시할 문자열로 변환한다(예:12,500, 2.5).
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return utils.FormatCommas(int(v))
+	}
+	return fmt.Sprintf("%.1f", v)
+}
@@ -0,0 +1,294 @@
+package analyze
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"html"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// 차트에 선으로 표시할 활동 분류의 최대 갯수(강좌가 많은 순서)
+const chartCategoryCount = 5
+
+// 강사 목록에 표시할 강좌명의 최대 갯수
+const teacherTitleCount = 3
+
+// Chart 분석 결과 문서에 포함되는 차트
+type Chart struct {
+	Name  string // 차트 이름(Markdown 형식으로 출력할 때 SVG 파일명에 사용된다)
+	Title string // 차트 제목
+	SVG   string // SVG 문서
+}
+
+// table 분석 결과 문서의 표
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// section 분석 결과 문서의 단락
+type section struct {
+	title  string
+	text   []string
+	charts []Chart
+	tables []table
+}
+
+// Charts 분석 결과의 차트 목록을 반환한다.
+func (r *Report) Charts() []Chart {
+	var charts []Chart
+	for _, s := range r.sections() {
+		charts = append(charts, s.charts...)
+	}
+	return charts
+}
+
+// WriteMarkdown 분석 결과를 Markdown 형식으로 출력한다.
+// 차트는 chartPath가 반환하는 경로(예:analysis-counts.svg)의 이미지로 참조되므로, 호출하는 쪽에서 Charts의 SVG 문서를 같은 경로에 저장해야 한다.
+func (r *Report) WriteMarkdown(w io.Writer, chartPath func(c Chart) string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# 문화센터 강좌 시즌 분석\n\n")
+	for _, s := range r.sections() {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", s.title))
+		for _, text := range s.text {
+			sb.WriteString(text + "\n\n")
+		}
+		for _, c := range s.charts {
+			sb.WriteString(fmt.Sprintf("![%s](%s)\n\n", c.Title, chartPath(c)))
+		}
+		for _, t := range s.tables {
+			sb.WriteString("| " + strings.Join(t.headers, " | ") + " |\n")
+			sb.WriteString(strings.Repeat("|---", len(t.headers)) + "|\n")
+			for _, row := range t.rows {
+				cells := make([]string, len(row))
+				for i, cell := range row {
+					cells[i] = strings.ReplaceAll(cell, "|", "\\|")
+				}
+				sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// WriteHTML 분석 결과를 차트가 포함된 하나의 HTML 문서로 출력한다.
+func (r *Report) WriteHTML(w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"ko\">\n<head>\n<meta charset=\"utf-8\">\n<title>문화센터 강좌 시즌 분석</title>\n")
+	sb.WriteString("<style>body{font-family:sans-serif;margin:2em;color:#333}table{border-collapse:collapse;margin:1em 0}th,td{border:1px solid #ccc;padding:4px 8px;text-align:left}th{background:#f5f5f5}svg{display:block;margin:1em 0}</style>\n")
+	sb.WriteString("</head>\n<body>\n<h1>문화센터 강좌 시즌 분석</h1>\n")
+	for _, s := range r.sections() {
+		sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(s.title)))
+		for _, text := range s.text {
+			sb.WriteString(fmt.Sprintf("<p>%s</p>\n", html.EscapeString(text)))
+		}
+		for _, c := range s.charts {
+			sb.WriteString(c.SVG)
+		}
+		for _, t := range s.tables {
+			sb.WriteString("<table>\n<tr>")
+			for _, header := range t.headers {
+				sb.WriteString("<th>" + html.EscapeString(header) + "</th>")
+			}
+			sb.WriteString("</tr>\n")
+			for _, row := range t.rows {
+				sb.WriteString("<tr>")
+				for _, cell := range row {
+					sb.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+				}
+				sb.WriteString("</tr>\n")
+			}
+			sb.WriteString("</table>\n")
+		}
+	}
+	sb.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// sections 분석 결과를 문서의 단락 목록으로 구성한다.
+func (r *Report) sections() []section {
+	labels := make([]string, len(r.Seasons))
+	for i, season := range r.Seasons {
+		labels[i] = season.String()
+	}
+
+	var sections []section
+
+	// 개요
+	overview := section{title: "개요"}
+	overview.text = append(overview.text, fmt.Sprintf("%d개 시즌(%s), %d번의 수집 결과를 분석하였습니다.", len(r.Seasons), strings.Join(labels, ", "), r.RunCount))
+	counts := make([]float64, len(r.Seasons))
+	for i, s := range r.Total.Seasons {
+		counts[i] = float64(s.LectureCount)
+	}
+	overview.charts = append(overview.charts, Chart{Name: "counts", Title: "시즌별 강좌 수", SVG: BarChart("시즌별 강좌 수", labels, counts, "")})
+	sections = append(sections, overview)
+
+	// 강좌 수
+	countTable := func(rows []Row) table {
+		t := table{headers: append(append([]string{"구분"}, labels...), "증감")}
+		for _, row := range rows {
+			cells := []string{row.Name}
+			for _, s := range row.Seasons {
+				cells = append(cells, fmt.Sprintf("%d", s.LectureCount))
+			}
+			cells = append(cells, seasonChange(row))
+			t.rows = append(t.rows, cells)
+		}
+		return t
+	}
+	sections = append(sections, section{
+		title:  "점포별 강좌 수",
+		text:   []string{"증감은 가장 최근 시즌과 직전 시즌의 강좌 수 차이입니다."},
+		tables: []table{countTable(append([]Row{r.Total}, r.Stores...))},
+	})
+	sections = append(sections, section{
+		title:  "활동 분류별 강좌 수",
+		tables: []table{countTable(r.Categories)},
+	})
+
+	// 수강료
+	prices := section{title: "평균 수강료 추이", text: []string{"각 칸은 평균 수강료와 재료비를 포함한 평균 1인 1회당 수강료입니다."}}
+	priceTable := table{headers: append([]string{"구분"}, labels...)}
+	series := []Series{seasonSeries(r.Total)}
+	for i, row := range append([]Row{r.Total}, r.Categories...) {
+		cells := []string{row.Name}
+		for _, s := range row.Seasons {
+			if s.AverageFee == 0 {
+				cells = append(cells, "-")
+				continue
+			}
+			cells = append(cells, fmt.Sprintf("%s원 (1회 %s원)", utils.FormatCommas(s.AverageFee), utils.FormatCommas(s.AverageSessionFee)))
+		}
+		priceTable.rows = append(priceTable.rows, cells)
+
+		if i > 0 && i <= chartCategoryCount {
+			series = append(series, seasonSeries(row))
+		}
+	}
+	prices.charts = append(prices.charts, Chart{Name: "prices", Title: "시즌별 평균 1인 1회당 수강료", SVG: LineChart("시즌별 평균 1인 1회당 수강료", labels, series, "원")})
+	prices.tables = append(prices.tables, priceTable)
+	sections = append(sections, prices)
+
+	// 마감 속도
+	closing := section{title: "마감 속도"}
+	if totalHours, totalClosed := r.Total.MedianHours(); totalClosed == 0 {
+		closing.text = append(closing.text, "접수가능 상태로 수집된 후 마감된 강좌가 없어 마감 속도를 계산할 수 없습니다. 접수 기간 중에 감시(watch) 명령 등으로 여러번 수집하면 마감 속도를 계산할 수 있습니다.")
+	} else {
+		closing.text = append(closing.text, fmt.Sprintf("접수가 시작된 후(접수시작일시를 모르면 접수가능 상태로 처음 수집된 시각부터) 마감(대기신청 포함)된 상태로 처음 수집될 때까지 걸린 시간입니다. 전체 중앙값은 %s이며, 수집 주기만큼의 오차가 있습니다.", formatHours(totalHours)))
+
+		hours := make([]float64, len(r.Seasons))
+		for i, s := range r.Total.Seasons {
+			hours[i] = math.NaN()
+			if s.ClosedCount > 0 {
+				hours[i] = s.MedianHours
+			}
+		}
+		closing.charts = append(closing.charts, Chart{Name: "closing", Title: "시즌별 마감까지 걸린 시간(중앙값)", SVG: BarChart("시즌별 마감까지 걸린 시간(중앙값)", labels, hours, "시간")})
+
+		closingTable := table{headers: append([]string{"구분"}, labels...)}
+		var plans []Row
+		for _, row := range append([]Row{r.Total}, r.Categories...) {
+			cells := []string{row.Name}
+			for _, s := range row.Seasons {
+				if s.TrackedCount == 0 {
+					cells = append(cells, "-")
+					continue
+				}
+				cell := fmt.Sprintf("%d/%d 마감", s.ClosedCount, s.TrackedCount)
+				if s.ClosedCount > 0 {
+					cell += fmt.Sprintf(", %s", formatHours(s.MedianHours))
+				}
+				cells = append(cells, cell)
+			}
+			closingTable.rows = append(closingTable.rows, cells)
+
+			if _, closed := row.MedianHours(); closed > 0 && row.Name != r.Total.Name {
+				plans = append(plans, row)
+			}
+		}
+		closing.tables = append(closing.tables, closingTable)
+
+		// 빨리 마감되는 활동 분류부터 수강신청 계획을 세울 수 있도록 정렬한다.
+		sort.SliceStable(plans, func(i, j int) bool {
+			hi, _ := plans[i].MedianHours()
+			hj, _ := plans[j].MedianHours()
+			return hi < hj
+		})
+		planTable := table{headers: []string{"활동 분류", "마감까지 걸린 시간(중앙값)", "마감된 강좌"}}
+		for _, row := range plans {
+			h, closed := row.MedianHours()
+			planTable.rows = append(planTable.rows, []string{row.Name, formatHours(h), fmt.Sprintf("%d건", closed)})
+		}
+		closing.tables = append(closing.tables, planTable)
+	}
+	sections = append(sections, closing)
+
+	// 강사
+	teachers := section{title: "여러 시즌에 강좌를 진행한 강사"}
+	if len(r.Teachers) == 0 {
+		teachers.text = append(teachers.text, "여러 시즌에 걸쳐 강좌를 진행한 강사가 없습니다(강사명을 제공하지 않는 체인은 제외됩니다).")
+	} else {
+		teacherTable := table{headers: []string{"강사", "시즌 수", "시즌", "점포", "강좌명"}}
+		for _, tc := range r.Teachers {
+			var seasons []string
+			for _, season := range tc.Seasons {
+				seasons = append(seasons, season.String())
+			}
+			titles := tc.Titles
+			if len(titles) > teacherTitleCount {
+				titles = append(titles[:teacherTitleCount:teacherTitleCount], fmt.Sprintf("외 %d개", len(tc.Titles)-teacherTitleCount))
+			}
+			teacherTable.rows = append(teacherTable.rows, []string{tc.Name, fmt.Sprintf("%d", len(tc.Seasons)), strings.Join(seasons, ", "), strings.Join(tc.Stores, ", "), strings.Join(titles, ", ")})
+		}
+		teachers.tables = append(teachers.tables, teacherTable)
+	}
+	sections = append(sections, teachers)
+
+	return sections
+}
+
+// seasonChange 가장 최근 시즌과 직전 시즌의 강좌 수 차이를 반환한다(예:+3, -2).
+func seasonChange(row Row) string {
+	if len(row.Seasons) < 2 {
+		return "-"
+	}
+	diff := row.Seasons[len(row.Seasons)-1].LectureCount - row.Seasons[len(row.Seasons)-2].LectureCount
+	if diff > 0 {
+		return fmt.Sprintf("+%d", diff)
+	}
+	return fmt.Sprintf("%d", diff)
+}
+
+// seasonSeries 시즌별 평균 1인 1회당 수강료를 선 차트의 계열로 반환한다.
+func seasonSeries(row Row) Series {
+	s := Series{Name: row.Name}
+	for _, stat := range row.Seasons {
+		if stat.AverageSessionFee == 0 {
+			s.Values = append(s.Values, math.NaN())
+			continue
+		}
+		s.Values = append(s.Values, float64(stat.AverageSessionFee))
+	}
+	return s
+}
+
+// formatHours 시간을 읽기 쉬운 문자열로 변환한다(예:5.5시간, 2.3일).
+func formatHours(hours float64) string {
+	if hours >= 48 {
+		return fmt.Sprintf("%.1f일", hours/24)
+	}
+	return fmt.Sprintf("%.1f시간", hours)
+}
@@ -0,0 +1,537 @@
+package config
+
+import (
+	"encoding/json"
+	"github.com/darkkaiser/culturelecture-scrape/age"
+	"github.com/darkkaiser/culturelecture-scrape/expr"
+	"github.com/darkkaiser/culturelecture-scrape/geo"
+	"github.com/darkkaiser/culturelecture-scrape/holiday"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"os"
+	"regexp"
+	"time"
+)
+
+// DefaultFileName 기본 설정 파일명
+const DefaultFileName = "culturelecture-scrape.json"
+
+// 지원가능한 문화센터 체인 ID
+const (
+	ChainHomeplus  = "homeplus"
+	ChainLottemart = "lottemart"
+	ChainEmart     = "emart"
+)
+
+type Config struct {
+	Chains   map[string]ChainConfig `json:"chains"`   // 문화센터 체인별 설정(키:체인 ID)
+	Detail   DetailConfig           `json:"detail"`   // 상세페이지 수집 설정
+	Snapshot SnapshotConfig         `json:"snapshot"` // 스냅샷 저장소 설정
+	Watch    WatchConfig            `json:"watch"`    // 접수상태 감시 설정
+	Notify   NotifyConfig           `json:"notify"`   // 알림 설정
+	Learners []LearnerConfig        `json:"learners"` // 강좌 수강자 목록
+	Export   ExportConfig           `json:"export"`   // 파일 저장 설정
+	Holidays []HolidayConfig        `json:"holidays"` // 계산되지 않는 임시공휴일 목록(예:선거일)
+	Filter   FilterConfig           `json:"filter"`   // 필터링 설정
+	Geo      GeoConfig              `json:"geo"`      // 점포 위치 및 거리 설정
+}
+
+type ChainConfig struct {
+	// 수집할 강좌군 목록
+	// 강좌군명(예:Kids 전체) 또는 체인 공통 연령대(baby, toddler, child, adult)를 지정할 수 있으며, 실행시에 강좌군 코드로 변환된다.
+	Groups []string `json:"groups"`
+
+	// 수집할 점포 목록
+	// 점포코드(예:560) 또는 점포명(예:여수)을 지정할 수 있으며, 지정하지 않으면 수집기에 등록된 전체 점포를 수집한다.
+	Stores []string `json:"stores"`
+
+	// 접수상태 문구별 접수상태(예: "온라인마감": "접수마감")
+	// 수집기가 지원하지 않는 접수상태 문구를 새로 추가하거나 기본 접수상태를 변경할 때 사용한다.
+	Statuses map[string]string `json:"statuses"`
+
+	// 강좌그룹 코드 또는 강좌그룹명별 체인 공통 활동 분류(예: "음악감성": "music")
+	// 지정하지 않은 강좌그룹은 강좌그룹명 및 강좌명의 키워드로 활동 분류를 추정한다.
+	Categories map[string]string `json:"categories"`
+
+	// 강좌의 연령제한에 사용되는 나이 계산 방식(international:만 나이, korean:세는 나이, year:연 나이)
+	// 수강자의 나이는 강좌의 개강일을 기준으로 이 방식에 따라 계산된다.
+	AgeConvention string `json:"ageConvention"`
+
+	// 공휴일에 휴강하는지의 여부
+	// 휴강하면 공휴일인 강의일자는 건너뛰고 다음 주로 미루어 강의일자를 계산한다.
+	SkipHolidays bool `json:"skipHolidays"`
+}
+
+type DetailConfig struct {
+	Enabled     bool   `json:"enabled"`     // 상세페이지에서 커리큘럼, 수강대상, 준비물, 강의실, 강의일자를 수집할지의 여부
+	Concurrency int    `json:"concurrency"` // 동시에 요청할 상세페이지 갯수
+	CacheDir    string `json:"cacheDir"`    // 상세페이지 캐시 디렉토리
+	CacheTTL    string `json:"cacheTTL"`    // 상세페이지 캐시 유효기간(예:24h)
+}
+
+type SnapshotConfig struct {
+	Enabled bool   `json:"enabled"` // 강좌 수집 결과를 스냅샷 저장소에 저장할지의 여부
+	Path    string `json:"path"`    // 스냅샷 저장소 파일 경로
+}
+
+type GeoConfig struct {
+	Home         LocationConfig            `json:"home"`         // 집 위치(지정하지 않으면 거리를 계산하지 않는다)
+	Stores       map[string]LocationConfig `json:"stores"`       // 점포명(예:이마트 여수점)별 위치(지정하지 않은 점포는 주소 또는 점포명의 시, 군 위치로 추정한다)
+	Speed        float64                   `json:"speed"`        // 이동시간 계산에 사용할 평균 주행 속도(km/h)
+	DetourFactor float64                   `json:"detourFactor"` // 직선거리 대비 도로거리의 비율(도로거리는 직선거리에 이 비율을 곱하여 추정한다)
+}
+
+type LocationConfig struct {
+	Address string  `json:"address"` // 주소
+	Phone   string  `json:"phone"`   // 전화번호
+	Lat     float64 `json:"lat"`     // 위도(0이면 주소의 시, 군 위치로 추정한다)
+	Lng     float64 `json:"lng"`     // 경도
+}
+
+type LearnerConfig struct {
+	Name            string   `json:"name"`            // 수강자 이름
+	Birthday        string   `json:"birthday"`        // 생년월일(YYYY-MM-DD)
+	Days            []string `json:"days"`            // 수강 가능한 요일 목록(예:토요일, 빈 목록이면 전체 요일)
+	TimeFrom        string   `json:"timeFrom"`        // 수강 가능한 시작시각(hh:mm, 빈 문자열이면 제한없음)
+	TimeTo          string   `json:"timeTo"`          // 수강 가능한 종료시각(hh:mm, 빈 문자열이면 제한없음)
+	ExcludeKeywords []string `json:"excludeKeywords"` // 강좌명에 포함되어 있으면 제외할 문자열 목록
+}
+
+type FilterConfig struct {
+	// 순서대로 적용할 필터링 규칙 목록
+	// 설정 파일에 지정하면 기본 필터링 규칙 목록을 대체한다.
+	Rules []FilterRuleConfig `json:"rules"`
+}
+
+// 지원가능한 필터링 규칙 유형
+const (
+	FilterRuleStatus       = "status"       // 접수상태(include, exclude)
+	FilterRuleTime         = "time"         // 요일별 시간대(days, timeFrom, timeTo, holidays)
+	FilterRuleKeyword      = "keyword"      // 강좌명 정규표현식(include, exclude)
+	FilterRuleAge          = "age"          // 수강자의 나이 및 개월수
+	FilterRulePrice        = "price"        // 수강료(max)
+	FilterRuleSessionPrice = "sessionPrice" // 1회당 수강료(max)
+	FilterRuleStore        = "store"        // 점포명(include, exclude)
+	FilterRuleGroup        = "group"        // 강좌그룹(include, exclude)
+	FilterRuleCategory     = "category"     // 체인 공통 활동 분류(include, exclude)
+	FilterRuleSessionCount = "sessionCount" // 강의횟수(min, max)
+	FilterRuleSessions     = "sessions"     // 주말, 공휴일 또는 16시 이후인 강의일자의 비율(min)
+	FilterRuleWhere        = "where"        // 조건식(expression)
+	FilterRuleDistance     = "distance"     // 집에서 점포까지의 거리(max, km)
+)
+
+// FilterRuleTypes 지원가능한 필터링 규칙 유형 목록
+var FilterRuleTypes = []string{FilterRuleStatus, FilterRuleTime, FilterRuleKeyword, FilterRuleAge, FilterRulePrice, FilterRuleSessionPrice, FilterRuleStore, FilterRuleGroup, FilterRuleCategory, FilterRuleSessionCount, FilterRuleSessions, FilterRuleWhere, FilterRuleDistance}
+
+// FilterRuleConfig 필터링 규칙
+// 규칙 유형에 따라 사용되는 항목이 다르며, 규칙을 만족하지 않는 강좌는 규칙 이름으로 제외된다.
+type FilterRuleConfig struct {
+	Name       string   `json:"name"`       // 규칙 이름(강좌가 제외된 사유로 기록된다)
+	Type       string   `json:"type"`       // 규칙 유형
+	Disabled   bool     `json:"disabled"`   // 규칙을 적용하지 않을지의 여부
+	Include    []string `json:"include"`    // 포함되어야 하는 값 목록(빈 목록이면 제한없음)
+	Exclude    []string `json:"exclude"`    // 포함되면 제외되는 값 목록
+	Days       []string `json:"days"`       // 시간대를 적용할 요일 목록(예:월, 빈 목록이면 전체 요일)
+	TimeFrom   string   `json:"timeFrom"`   // 시작시간이 이 시각 이후이어야 한다(hh:mm, 빈 문자열이면 제한없음)
+	TimeTo     string   `json:"timeTo"`     // 종료시간이 이 시각 이전이어야 한다(hh:mm, 빈 문자열이면 제한없음)
+	Holidays   bool     `json:"holidays"`   // 개강일이 공휴일이면 시간대를 적용하지 않을지의 여부
+	Unknown    bool     `json:"unknown"`    // 점포 위치를 알 수 없어 거리를 계산하지 못한 강좌도 제외할지의 여부(distance 규칙)
+	Min        float64  `json:"min"`        // 최소값
+	Max        float64  `json:"max"`        // 최대값(0이면 제한없음)
+	Expression string   `json:"expression"` // 강좌가 만족해야 하는 조건식(예:day in [토,일] && price <= 50000)
+}
+
+type HolidayConfig struct {
+	Date string `json:"date"` // 날짜(YYYY-MM-DD)
+	Name string `json:"name"` // 공휴일명
+}
+
+type ExportConfig struct {
+	LearnerFiles   bool `json:"learnerFiles"`   // 수강자별로 파일을 따로 저장할지의 여부(false이면 하나의 파일에 수강자 항목을 추가한다)
+	SortByDistance bool `json:"sortByDistance"` // 집에서 가까운 점포의 강좌부터 저장할지의 여부(집 위치가 지정된 경우)
+}
+
+type WatchConfig struct {
+	Interval   string              `json:"interval"`   // 수집 주기(예:10m)
+	Jitter     string              `json:"jitter"`     // 수집 주기에 임의로 더하거나 빼는 최대 시간(예:1m)
+	QuietHours string              `json:"quietHours"` // 수집하지 않는 시간대(예:23:00-07:00, 빈 문자열이면 항상 수집)
+	Chains     map[string][]string `json:"chains"`     // 수집할 체인 ID별 점포 목록(빈 목록이면 체인의 전체 점포, 지정하지 않으면 전체 체인)
+	Lectures   []string            `json:"lectures"`   // 감시할 강좌 ID 목록
+	Titles     []string            `json:"titles"`     // 감시할 강좌명에 포함된 문자열 목록(강좌 ID 및 강좌명을 모두 지정하지 않으면 전체 강좌를 감시한다)
+}
+
+type NotifyConfig struct {
+	Stdout   bool                `json:"stdout"`   // 알림을 표준출력으로 출력할지의 여부
+	Template string              `json:"template"` // 알림 메시지 템플릿(text/template 형식, 빈 문자열이면 기본 템플릿)
+	Webhook  WebhookNotifyConfig `json:"webhook"`  // 웹훅 알림 설정
+	SMTP     SMTPNotifyConfig    `json:"smtp"`     // 이메일 알림 설정
+	Command  CommandNotifyConfig `json:"command"`  // 명령 실행 알림 설정
+}
+
+type WebhookNotifyConfig struct {
+	Enabled bool   `json:"enabled"` // 웹훅 알림 사용 여부
+	URL     string `json:"url"`     // 알림 이벤트를 JSON으로 POST할 주소
+}
+
+type SMTPNotifyConfig struct {
+	Enabled  bool     `json:"enabled"`  // 이메일 알림 사용 여부
+	Host     string   `json:"host"`     // SMTP 서버
+	Port     int      `json:"port"`     // SMTP 서버 포트
+	Username string   `json:"username"` // SMTP 인증 사용자(빈 문자열이면 인증하지 않는다)
+	Password string   `json:"password"` // SMTP 인증 비밀번호
+	From     string   `json:"from"`     // 보내는 사람
+	To       []string `json:"to"`       // 받는 사람 목록
+}
+
+type CommandNotifyConfig struct {
+	Enabled bool     `json:"enabled"` // 명령 실행 알림 사용 여부
+	Path    string   `json:"path"`    // 실행할 명령(알림 이벤트가 JSON으로 표준입력에 전달된다)
+	Args    []string `json:"args"`    // 명령 인자
+	Timeout string   `json:"timeout"` // 명령 실행 제한시간(예:30s)
+}
+
+// CacheTTLDuration 상세페이지 캐시 유효기간을 반환한다.
+func (dc DetailConfig) CacheTTLDuration() time.Duration {
+	d, err := time.ParseDuration(dc.CacheTTL)
+	utils.CheckErr(err)
+	return d
+}
+
+// IntervalDuration 수집 주기를 반환한다.
+func (wc WatchConfig) IntervalDuration() time.Duration {
+	d, err := time.ParseDuration(wc.Interval)
+	utils.CheckErr(err)
+	return d
+}
+
+// JitterDuration 수집 주기에 임의로 더하거나 빼는 최대 시간을 반환한다.
+func (wc WatchConfig) JitterDuration() time.Duration {
+	d, err := time.ParseDuration(wc.Jitter)
+	utils.CheckErr(err)
+	return d
+}
+
+// BirthDate 수강자의 생년월일을 반환한다.
+func (lc LearnerConfig) BirthDate() time.Time {
+	t, err := time.ParseInLocation("2006-01-02", lc.Birthday, time.Local)
+	utils.CheckErr(err)
+	return t
+}
+
+// Convention 강좌의 연령제한에 사용되는 나이 계산 방식을 반환한다.
+func (cc ChainConfig) Convention() age.Convention {
+	c, ok := age.ParseConvention(cc.AgeConvention)
+	if ok == false {
+		return age.Korean
+	}
+	return c
+}
+
+// HolidayCalendar 임시공휴일이 추가된 공휴일 달력을 반환한다.
+func (c *Config) HolidayCalendar() *holiday.Calendar {
+	var extra []holiday.Holiday
+	for _, hc := range c.Holidays {
+		extra = append(extra, holiday.Holiday{Date: hc.Date, Name: hc.Name})
+	}
+	return holiday.NewCalendar(extra)
+}
+
+// TimeoutDuration 명령 실행 제한시간을 반환한다.
+func (cc CommandNotifyConfig) TimeoutDuration() time.Duration {
+	d, err := time.ParseDuration(cc.Timeout)
+	utils.CheckErr(err)
+	return d
+}
+
+// Default 설정 파일이 없을 때 사용되는 기본 설정을 반환한다.
+func Default() *Config {
+	return &Config{
+		Chains: map[string]ChainConfig{
+			ChainHomeplus: {
+				Groups:        []string{"Kids 전체", "Baby 전체"},
+				AgeConvention: string(age.Korean),
+			},
+			ChainLottemart: {
+				Groups:        []string{"baby", "toddler", "child"},
+				AgeConvention: string(age.Korean),
+			},
+			ChainEmart: {
+				Groups:        []string{"With Mom", "With mom(event)", "Kids & Children", "Kids & Children(event)"},
+				AgeConvention: string(age.Korean),
+			},
+		},
+		Detail: DetailConfig{
+			Enabled:     false,
+			Concurrency: 4,
+			CacheDir:    ".cache/detail",
+			CacheTTL:    "24h",
+		},
+		Filter: FilterConfig{
+			Rules: []FilterRuleConfig{
+				{Name: "closed", Type: FilterRuleStatus, Exclude: []string{"접수마감"}},
+				{Name: "weekday", Type: FilterRuleTime, Days: []string{"월", "화", "수", "목", "금"}, TimeFrom: "16:00", Holidays: true},
+				{Name: "title", Type: FilterRuleKeyword, Exclude: []string{"키즈발레", "영어발레", "엔젤 ?발레", "체형교정 ?발레", "YSM ?발레", "쁘띠발레", "발레리나", "앨리스 스토리텔링 발레", "트윈클 동화발레", "밸리댄스", "\\[광주국제영어마을"}},
+				{Name: "age", Type: FilterRuleAge},
+				{Name: "sessions", Type: FilterRuleSessions, Min: 0.5, Disabled: true},
+			},
+		},
+		Geo: GeoConfig{
+			Speed:        40,
+			DetourFactor: 1.3,
+		},
+		Snapshot: SnapshotConfig{
+			Enabled: true,
+			Path:    "culturelecture-scrape.db",
+		},
+		Watch: WatchConfig{
+			Interval: "10m",
+			Jitter:   "1m",
+		},
+		Notify: NotifyConfig{
+			Stdout: true,
+			SMTP: SMTPNotifyConfig{
+				Port: 587,
+			},
+			Command: CommandNotifyConfig{
+				Timeout: "30s",
+			},
+		},
+	}
+}
+
+// Load 설정 파일을 읽어들인다.
+// 설정 파일이 존재하지 않으면 기본 설정을 반환하며, 설정 파일에 지정되지 않은 항목은 기본 설정값이 사용된다.
+func Load(fileName string) *Config {
+	config := Default()
+
+	data, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) == true {
+		return config
+	}
+	utils.CheckErr(err)
+
+	// 체인별 설정은 항목별로 기본 설정값과 병합되어야 하므로 별도로 읽어들인다.
+	var fileChains struct {
+		Chains map[string]json.RawMessage `json:"chains"`
+	}
+	if err = json.Unmarshal(data, &fileChains); err != nil {
+		log.Fatalf("설정 파일(%s)을 읽어들이는 중에 오류가 발생하였습니다(%s)", fileName, err)
+	}
+
+	// 필터링 규칙 목록은 기본 필터링 규칙 목록과 병합되지 않고 대체되어야 하므로, 비워둔 상태에서 읽어들인다.
+	// 체인별 설정도 비워둔 상태에서 읽어들인다. 기본 설정값이 담긴 맵에 그대로 읽어들이면 설정 파일에 포함된 체인의 기본 설정값이 모두 지워진다.
+	chains, filterRules := config.Chains, config.Filter.Rules
+	config.Chains, config.Filter.Rules = nil, nil
+	if err = json.Unmarshal(data, config); err != nil {
+		log.Fatalf("설정 파일(%s)을 읽어들이는 중에 오류가 발생하였습니다(%s)", fileName, err)
+	}
+	config.Chains = chains
+	if config.Filter.Rules == nil {
+		config.Filter.Rules = filterRules
+	}
+
+	for chain, raw := range fileChains.Chains {
+		cc, exists := config.Chains[chain]
+		if exists == false {
+			log.Fatalf("설정 파일(%s)에 지원하지 않는 문화센터 체인 ID가 포함되어 있습니다(체인 ID:%s)", fileName, chain)
+		}
+
+		if err = json.Unmarshal(raw, &cc); err != nil {
+			log.Fatalf("설정 파일(%s)을 읽어들이는 중에 오류가 발생하였습니다(체인 ID:%s, %s)", fileName, chain, err)
+		}
+
+		for text, status := range cc.Statuses {
+			if _, ok := lectures.ParseReceptionStatus(status); ok == false {
+				log.Fatalf("설정 파일(%s)에 지원하지 않는 접수상태가 포함되어 있습니다(체인 ID:%s, 접수상태 문구:%s, 접수상태:%s)", fileName, chain, text, status)
+			}
+		}
+
+		for group, category := range cc.Categories {
+			if _, ok := lectures.ParseCategory(category); ok == false {
+				log.Fatalf("설정 파일(%s)에 지원하지 않는 활동 분류가 포함되어 있습니다(체인 ID:%s, 강좌그룹:%s, 활동 분류:%s)", fileName, chain, group, category)
+			}
+		}
+
+		if _, ok := age.ParseConvention(cc.AgeConvention); ok == false {
+			log.Fatalf("설정 파일(%s)에 지원하지 않는 나이 계산 방식이 포함되어 있습니다(체인 ID:%s, ageConvention:%s)", fileName, chain, cc.AgeConvention)
+		}
+
+		config.Chains[chain] = cc
+	}
+
+	if _, err = time.ParseDuration(config.Detail.CacheTTL); err != nil {
+		log.Fatalf("설정 파일(%s)의 상세페이지 캐시 유효기간이 올바르지 않습니다(cacheTTL:%s)", fileName, config.Detail.CacheTTL)
+	}
+	if config.Detail.Concurrency < 1 {
+		log.Fatalf("설정 파일(%s)의 상세페이지 동시 요청 갯수는 1 이상이어야 합니다(concurrency:%d)", fileName, config.Detail.Concurrency)
+	}
+
+	interval, err := time.ParseDuration(config.Watch.Interval)
+	if err != nil || interval <= 0 {
+		log.Fatalf("설정 파일(%s)의 접수상태 감시 수집 주기가 올바르지 않습니다(interval:%s)", fileName, config.Watch.Interval)
+	}
+	jitter, err := time.ParseDuration(config.Watch.Jitter)
+	if err != nil || jitter < 0 || jitter >= interval {
+		log.Fatalf("설정 파일(%s)의 접수상태 감시 수집 주기 편차는 0 이상, 수집 주기 미만이어야 합니다(jitter:%s)", fileName, config.Watch.Jitter)
+	}
+	for chain := range config.Watch.Chains {
+		if _, exists := config.Chains[chain]; exists == false {
+			log.Fatalf("설정 파일(%s)의 접수상태 감시 설정에 지원하지 않는 문화센터 체인 ID가 포함되어 있습니다(체인 ID:%s)", fileName, chain)
+		}
+	}
+
+	if config.Geo.Speed <= 0 || config.Geo.DetourFactor < 1 {
+		log.Fatalf("설정 파일(%s)의 평균 주행 속도는 0보다 크고, 도로거리 비율은 1 이상이어야 합니다(speed:%g, detourFactor:%g)", fileName, config.Geo.Speed, config.Geo.DetourFactor)
+	}
+	for name, lc := range config.Geo.Stores {
+		if (lc.Lat != 0 || lc.Lng != 0) && lc.Point().Valid() == false {
+			log.Fatalf("설정 파일(%s)의 점포 좌표가 올바르지 않습니다(점포:%s, lat:%g, lng:%g)", fileName, name, lc.Lat, lc.Lng)
+		}
+	}
+	if config.Geo.Home != (LocationConfig{}) {
+		if _, _, ok := config.Geo.HomeLocation(); ok == false {
+			log.Fatalf("설정 파일(%s)의 집 위치를 알 수 없습니다(좌표(lat, lng)를 지정하거나 시, 군 이름이 포함된 주소를 지정하세요, address:%s)", fileName, config.Geo.Home.Address)
+		}
+	}
+
+	validateFilterRules(fileName, config.Filter.Rules)
+	for _, rc := range config.Filter.Rules {
+		if _, _, ok := config.Geo.HomeLocation(); rc.Type == FilterRuleDistance && rc.Disabled == false && ok == false {
+			log.Fatalf("설정 파일(%s)의 거리 필터링 규칙을 사용하려면 집 위치(geo.home)를 지정해야 합니다(규칙:%s)", fileName, rc.Name)
+		}
+	}
+
+	for _, hc := range config.Holidays {
+		if _, err = time.ParseInLocation("2006-01-02", hc.Date, time.Local); err != nil || hc.Name == "" {
+			log.Fatalf("설정 파일(%s)의 임시공휴일이 올바르지 않습니다(date:%s, name:%s)", fileName, hc.Date, hc.Name)
+		}
+	}
+
+	learnerNames := make(map[string]bool)
+	for _, lc := range config.Learners {
+		if lc.Name == "" || learnerNames[lc.Name] == true {
+			log.Fatalf("설정 파일(%s)의 수강자 이름은 비어 있거나 중복될 수 없습니다(수강자:%s)", fileName, lc.Name)
+		}
+		learnerNames[lc.Name] = true
+
+		if _, err = time.ParseInLocation("2006-01-02", lc.Birthday, time.Local); err != nil {
+			log.Fatalf("설정 파일(%s)의 수강자 생년월일이 올바르지 않습니다(수강자:%s, birthday:%s)", fileName, lc.Name, lc.Birthday)
+		}
+		for _, v := range []string{lc.TimeFrom, lc.TimeTo} {
+			if _, err = time.Parse("15:04", v); v != "" && err != nil {
+				log.Fatalf("설정 파일(%s)의 수강자 수강 가능 시각이 올바르지 않습니다(수강자:%s, 시각:%s)", fileName, lc.Name, v)
+			}
+		}
+	}
+
+	if config.Notify.Webhook.Enabled == true && config.Notify.Webhook.URL == "" {
+		log.Fatalf("설정 파일(%s)의 웹훅 알림 주소가 지정되지 않았습니다", fileName)
+	}
+	if config.Notify.SMTP.Enabled == true && (config.Notify.SMTP.Host == "" || config.Notify.SMTP.From == "" || len(config.Notify.SMTP.To) == 0) {
+		log.Fatalf("설정 파일(%s)의 이메일 알림 설정에 SMTP 서버, 보내는 사람, 받는 사람이 모두 지정되어야 합니다", fileName)
+	}
+	if config.Notify.Command.Enabled == true {
+		if config.Notify.Command.Path == "" {
+			log.Fatalf("설정 파일(%s)의 명령 실행 알림에 실행할 명령이 지정되지 않았습니다", fileName)
+		}
+		if _, err = time.ParseDuration(config.Notify.Command.Timeout); err != nil {
+			log.Fatalf("설정 파일(%s)의 명령 실행 알림 제한시간이 올바르지 않습니다(timeout:%s)", fileName, config.Notify.Command.Timeout)
+		}
+	}
+
+	return config
+}
+
+// Point 지정된 좌표를 반환한다.
+func (lc LocationConfig) Point() geo.Point {
+	return geo.Point{Lat: lc.Lat, Lng: lc.Lng}
+}
+
+// Locate 위치를 반환한다. 좌표가 지정되지 않았으면 주소의 시, 군 위치로 추정하며(approx가 true), 위치를 알 수 없으면 false를 반환한다.
+func (lc LocationConfig) Locate() (p geo.Point, approx bool, ok bool) {
+	if lc.Point().Valid() == true {
+		return lc.Point(), false, true
+	}
+	if p, _, ok = geo.LookupCity(lc.Address); ok == true {
+		return p, true, true
+	}
+	return geo.Point{}, false, false
+}
+
+// HomeLocation 집 위치를 반환한다. 집 위치가 지정되지 않았거나 알 수 없으면 false를 반환한다.
+func (gc GeoConfig) HomeLocation() (p geo.Point, approx bool, ok bool) {
+	return gc.Home.Locate()
+}
+
+// Chain 체인 ID에 해당하는 체인 설정을 반환한다.
+func (c *Config) Chain(chain string) ChainConfig {
+	cc, exists := c.Chains[chain]
+	if exists == false {
+		log.Fatalf("지원하지 않는 문화센터 체인 ID입니다(체인 ID:%s)", chain)
+	}
+	return cc
+}
+
+// ReceptionStatuses 설정된 접수상태 문구별 접수상태를 반환한다.
+func (cc ChainConfig) ReceptionStatuses() map[string]lectures.ReceptionStatus {
+	statuses := make(map[string]lectures.ReceptionStatus)
+	for text, status := range cc.Statuses {
+		statuses[text], _ = lectures.ParseReceptionStatus(status)
+	}
+	return statuses
+}
+
+// validateFilterRules 필터링 규칙 목록이 올바른지 확인한다.
+func validateFilterRules(fileName string, rules []FilterRuleConfig) {
+	ruleNames := make(map[string]bool)
+	for _, rc := range rules {
+		if rc.Name == "" || ruleNames[rc.Name] == true {
+			log.Fatalf("설정 파일(%s)의 필터링 규칙 이름은 비어 있거나 중복될 수 없습니다(규칙:%s)", fileName, rc.Name)
+		}
+		ruleNames[rc.Name] = true
+
+		if utils.Contains(FilterRuleTypes, rc.Type) == false {
+			log.Fatalf("설정 파일(%s)에 지원하지 않는 필터링 규칙 유형이 포함되어 있습니다(규칙:%s, type:%s)", fileName, rc.Name, rc.Type)
+		}
+
+		switch rc.Type {
+		case FilterRuleStatus:
+			for _, status := range append(append([]string{}, rc.Include...), rc.Exclude...) {
+				if _, ok := lectures.ParseReceptionStatus(status); ok == false {
+					log.Fatalf("설정 파일(%s)의 필터링 규칙에 지원하지 않는 접수상태가 포함되어 있습니다(규칙:%s, 접수상태:%s)", fileName, rc.Name, status)
+				}
+			}
+		case FilterRuleCategory:
+			for _, category := range append(append([]string{}, rc.Include...), rc.Exclude...) {
+				if _, ok := lectures.ParseCategory(category); ok == false {
+					log.Fatalf("설정 파일(%s)의 필터링 규칙에 지원하지 않는 활동 분류가 포함되어 있습니다(규칙:%s, 활동 분류:%s)", fileName, rc.Name, category)
+				}
+			}
+		case FilterRuleKeyword:
+			for _, pattern := range append(append([]string{}, rc.Include...), rc.Exclude...) {
+				if _, err := regexp.Compile(pattern); err != nil {
+					log.Fatalf("설정 파일(%s)의 필터링 규칙에 올바르지 않은 정규표현식이 포함되어 있습니다(규칙:%s, 정규표현식:%s, %s)", fileName, rc.Name, pattern, err)
+				}
+			}
+		case FilterRuleTime:
+			for _, v := range []string{rc.TimeFrom, rc.TimeTo} {
+				if _, err := time.Parse("15:04", v); v != "" && err != nil {
+					log.Fatalf("설정 파일(%s)의 필터링 규칙 시각이 올바르지 않습니다(규칙:%s, 시각:%s)", fileName, rc.Name, v)
+				}
+			}
+		case FilterRuleWhere:
+			if _, err := expr.Parse(rc.Expression); err != nil {
+				log.Fatalf("설정 파일(%s)의 필터링 규칙 조건식이 올바르지 않습니다(규칙:%s, %s)\n%s", fileName, rc.Name, err, err.(*expr.SyntaxError).Pointer())
+			}
+		case FilterRuleSessions:
+			if rc.Min < 0 || rc.Min > 1 {
+				log.Fatalf("설정 파일(%s)의 강의일자 최소 비율은 0 이상, 1 이하이어야 합니다(규칙:%s, min:%g)", fileName, rc.Name, rc.Min)
+			}
+		}
+
+		if rc.Min < 0 || rc.Max < 0 || (rc.Max > 0 && rc.Min > rc.Max) {
+			log.Fatalf("설정 파일(%s)의 필터링 규칙 최소값 및 최대값이 올바르지 않습니다(규칙:%s, min:%g, max:%g)", fileName, rc.Name, rc.Min, rc.Max)
+		}
+	}
+}
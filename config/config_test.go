@@ -0,0 +1,75 @@
+package config
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/age"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeConfig 설정 파일을 임시 디렉토리에 저장하고 파일 경로를 반환한다.
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), DefaultFileName)
+	if err := os.WriteFile(fileName, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return fileName
+}
+
+func TestLoadMergesPartialChainOverride(t *testing.T) {
+	config := Load(writeConfig(t, `{"chains":{"lottemart":{"statuses":{"온라인마감":"접수마감"}}}}`))
+	defaults := Default()
+
+	lottemart := config.Chains[ChainLottemart]
+	if lottemart.Statuses["온라인마감"] != "접수마감" {
+		t.Errorf("statuses = %v, 설정 파일의 접수상태 문구가 적용되지 않았습니다", lottemart.Statuses)
+	}
+	if reflect.DeepEqual(lottemart.Groups, defaults.Chains[ChainLottemart].Groups) == false {
+		t.Errorf("groups = %v, want %v", lottemart.Groups, defaults.Chains[ChainLottemart].Groups)
+	}
+	if lottemart.AgeConvention != string(age.Korean) {
+		t.Errorf("ageConvention = %q, want %q", lottemart.AgeConvention, age.Korean)
+	}
+
+	// 설정 파일에 포함되지 않은 체인은 기본 설정값을 그대로 사용한다.
+	for _, chain := range []string{ChainHomeplus, ChainEmart} {
+		if reflect.DeepEqual(config.Chains[chain], defaults.Chains[chain]) == false {
+			t.Errorf("chains[%s] = %+v, want %+v", chain, config.Chains[chain], defaults.Chains[chain])
+		}
+	}
+}
+
+func TestLoadReplacesChainFields(t *testing.T) {
+	config := Load(writeConfig(t, `{"chains":{"emart":{"groups":["With Mom"],"ageConvention":"international","skipHolidays":true}}}`))
+
+	emart := config.Chains[ChainEmart]
+	if reflect.DeepEqual(emart.Groups, []string{"With Mom"}) == false {
+		t.Errorf("groups = %v, want [With Mom]", emart.Groups)
+	}
+	if emart.AgeConvention != string(age.International) || emart.SkipHolidays == false {
+		t.Errorf("ageConvention = %q, skipHolidays = %v", emart.AgeConvention, emart.SkipHolidays)
+	}
+}
+
+func TestLoadKeepsDefaultsWithoutChains(t *testing.T) {
+	config := Load(writeConfig(t, `{"detail":{"enabled":true}}`))
+
+	if reflect.DeepEqual(config.Chains, Default().Chains) == false {
+		t.Errorf("chains = %+v, want defaults", config.Chains)
+	}
+	if config.Detail.Enabled == false || config.Detail.Concurrency != 4 {
+		t.Errorf("detail = %+v, 기본 설정값과 병합되지 않았습니다", config.Detail)
+	}
+}
+
+func TestDefaultSessionsRuleDisabled(t *testing.T) {
+	// 강의일자 비율 규칙은 기본 수집 결과를 바꾸지 않도록 사용하지 않는 상태로 제공된다.
+	for _, rc := range Default().Filter.Rules {
+		if rc.Type == FilterRuleSessions && rc.Disabled == false {
+			t.Errorf("기본 필터링 규칙(%s)이 사용하도록 설정되어 있습니다", rc.Name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/diff"
+	"github.com/darkkaiser/culturelecture-scrape/notify"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"github.com/darkkaiser/culturelecture-scrape/watch"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// diffCommand 두 수집 결과를 비교하여 추가, 삭제, 변경된 강좌를 출력한다.
+// 비교 대상은 스냅샷 저장소의 실행 ID 또는 내보낸 강좌 파일(.csv, .json)이며, 지정하지 않으면 전체 체인을 수집한 가장 최근의 두 실행을 비교한다.
+func diffCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", diff.FormatText, "출력 형식(text, markdown, json)")
+	notifyChanges := fs.Bool("notify", false, "감시 대상 강좌의 신규 수집 및 접수상태 변경을 알림 채널로 전달할지의 여부")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "사용법: diff [-format text|markdown|json] [-notify] [이전 실행 ID 또는 파일] [이후 실행 ID 또는 파일]")
+		fs.PrintDefaults()
+	}
+	utils.CheckErr(fs.Parse(args))
+
+	var oldLectures, newLectures []lectures.Lecture
+	switch fs.NArg() {
+	case 0:
+		store, err := snapshot.Open(cfg.Snapshot.Path)
+		utils.CheckErr(err)
+
+		runs, err := store.FullRuns()
+		utils.CheckErr(err)
+		if len(runs) < 2 {
+			log.Fatalf("스냅샷 저장소(%s)에 전체 체인을 수집한 실행이 2개 이상 존재하지 않습니다(실행 갯수:%d)", cfg.Snapshot.Path, len(runs))
+		}
+
+		oldLectures, err = store.Lectures(runs[len(runs)-2].ID)
+		utils.CheckErr(err)
+		newLectures, err = store.Lectures(runs[len(runs)-1].ID)
+		utils.CheckErr(err)
+
+		utils.CheckErr(store.Close())
+	case 2:
+		oldLectures = loadDiffSource(cfg, fs.Arg(0))
+		newLectures = loadDiffSource(cfg, fs.Arg(1))
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	utils.CheckErr(diff.Write(os.Stdout, *format, diff.Compare(oldLectures, newLectures)))
+
+	if *notifyChanges == true {
+		notifier, err := notify.New(cfg.Notify)
+		utils.CheckErr(err)
+
+		events := watch.Events(time.Now(), oldLectures, newLectures, watch.NewMatcher(cfg.Watch.Lectures, cfg.Watch.Titles))
+		if len(events) > 0 {
+			utils.CheckErr(notifier.Notify(events))
+		}
+		log.Printf("감시 대상 강좌의 변경 이벤트 %d건을 알림 채널로 전달하였습니다.", len(events))
+	}
+}
+
+// loadDiffSource 실행 ID 또는 파일에서 비교할 강좌 목록을 읽어들인다.
+func loadDiffSource(cfg *config.Config, source string) []lectures.Lecture {
+	runID, err := strconv.ParseUint(source, 10, 64)
+	if err != nil {
+		lectureList, err := scrape.ReadFile(source)
+		utils.CheckErr(err)
+		return lectureList
+	}
+
+	store, err := snapshot.Open(cfg.Snapshot.Path)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer store.Close()
+
+	lectureList, err := store.Lectures(runID)
+	utils.CheckErr(err)
+
+	return lectureList
+}
@@ -0,0 +1,153 @@
+package diff
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"sort"
+	"strings"
+)
+
+// ChangeType 강좌 변경 유형
+type ChangeType string
+
+// 지원가능한 강좌 변경 유형 값
+const (
+	ChangeAdded   ChangeType = "added"   // 추가
+	ChangeRemoved ChangeType = "removed" // 삭제
+	ChangeChanged ChangeType = "changed" // 변경
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "추가"
+	case ChangeRemoved:
+		return "삭제"
+	case ChangeChanged:
+		return "변경"
+	default:
+		return string(t)
+	}
+}
+
+// FieldChange 강좌 항목의 변경 내역
+type FieldChange struct {
+	Field string `json:"field"` // 항목명(예:접수상태)
+	Old   string `json:"old"`   // 변경전 값
+	New   string `json:"new"`   // 변경후 값
+}
+
+// Change 강좌의 변경 내역
+type Change struct {
+	Type    ChangeType        `json:"type"`             // 변경 유형
+	ID      string            `json:"id"`               // 강좌 ID
+	Old     *lectures.Lecture `json:"old,omitempty"`    // 변경전 강좌(추가된 강좌는 nil)
+	New     *lectures.Lecture `json:"new,omitempty"`    // 변경후 강좌(삭제된 강좌는 nil)
+	Changes []FieldChange     `json:"fields,omitempty"` // 항목별 변경 내역(변경된 강좌만 해당)
+}
+
+// Lecture 변경 내역의 대상 강좌를 반환한다. 삭제된 강좌는 변경전 강좌를 반환한다.
+func (c *Change) Lecture() *lectures.Lecture {
+	if c.New != nil {
+		return c.New
+	}
+	return c.Old
+}
+
+// FieldChange 항목명에 해당하는 항목의 변경 내역을 반환한다. 변경되지 않은 항목이면 nil을 반환한다.
+func (c *Change) FieldChange(field string) *FieldChange {
+	for i := range c.Changes {
+		if c.Changes[i].Field == field {
+			return &c.Changes[i]
+		}
+	}
+	return nil
+}
+
+// 변경 여부를 비교하는 강좌 항목명
+const (
+	FieldStatus    = "접수상태"
+	FieldPrice     = "수강료"
+	FieldTime      = "강의시간"
+	FieldStartDate = "개강일"
+	FieldTeacher   = "강사명"
+	FieldTitle     = "강좌명"
+	FieldCount     = "강좌횟수"
+)
+
+// 변경 여부를 비교하는 강좌 항목
+var fields = []struct {
+	name  string
+	value func(lecture *lectures.Lecture) string
+}{
+	{FieldStatus, func(l *lectures.Lecture) string {
+		if l.Status == lectures.ReceptionStatusUnknown && l.StatusText != "" {
+			return l.Status.String() + "(" + l.StatusText + ")"
+		}
+		return l.Status.String()
+	}},
+	{FieldPrice, func(l *lectures.Lecture) string { return l.Price }},
+	{FieldTime, func(l *lectures.Lecture) string {
+		return strings.TrimSpace(l.DayOfTheWeek + " " + l.StartTime + "~" + l.EndTime)
+	}},
+	{FieldStartDate, func(l *lectures.Lecture) string { return l.StartDate }},
+	{FieldTeacher, func(l *lectures.Lecture) string { return l.Teacher }},
+	{FieldTitle, func(l *lectures.Lecture) string { return l.Title }},
+	{FieldCount, func(l *lectures.Lecture) string { return l.Count }},
+}
+
+// Compare 두 강좌 목록을 강좌 ID로 비교하여 추가, 삭제, 변경된 강좌의 변경 내역을 강좌 ID 순서로 반환한다.
+func Compare(oldLectures, newLectures []lectures.Lecture) []Change {
+	oldLectureMap := make(map[string]*lectures.Lecture)
+	for i := range oldLectures {
+		oldLectureMap[lectures.Key(&oldLectures[i])] = &oldLectures[i]
+	}
+	newLectureMap := make(map[string]*lectures.Lecture)
+	for i := range newLectures {
+		newLectureMap[lectures.Key(&newLectures[i])] = &newLectures[i]
+	}
+
+	var changes []Change
+	for id, newLecture := range newLectureMap {
+		oldLecture, exists := oldLectureMap[id]
+		if exists == false {
+			changes = append(changes, Change{Type: ChangeAdded, ID: id, New: newLecture})
+			continue
+		}
+
+		var fieldChanges []FieldChange
+		for _, f := range fields {
+			if o, n := f.value(oldLecture), f.value(newLecture); o != n {
+				fieldChanges = append(fieldChanges, FieldChange{Field: f.name, Old: o, New: n})
+			}
+		}
+		if len(fieldChanges) > 0 {
+			changes = append(changes, Change{Type: ChangeChanged, ID: id, Old: oldLecture, New: newLecture, Changes: fieldChanges})
+		}
+	}
+	for id, oldLecture := range oldLectureMap {
+		if _, exists := newLectureMap[id]; exists == false {
+			changes = append(changes, Change{Type: ChangeRemoved, ID: id, Old: oldLecture})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ID < changes[j].ID
+	})
+
+	return changes
+}
+
+// Count 변경 유형별 변경 내역의 갯수를 반환한다.
+func Count(changes []Change) (added, removed, changed int) {
+	for _, c := range changes {
+		switch c.Type {
+		case ChangeAdded:
+			added++
+		case ChangeRemoved:
+			removed++
+		case ChangeChanged:
+			changed++
+		}
+	}
+	return
+}
@@ -0,0 +1,111 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 지원가능한 출력 형식
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// Write 변경 내역을 지정된 출력 형식으로 출력한다.
+func Write(w io.Writer, format string, changes []Change) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, changes)
+	case FormatMarkdown:
+		return WriteMarkdown(w, changes)
+	case FormatJSON:
+		return WriteJSON(w, changes)
+	default:
+		return fmt.Errorf("지원하지 않는 출력 형식입니다(출력 형식:%s, 지원형식:%s, %s, %s)", format, FormatText, FormatMarkdown, FormatJSON)
+	}
+}
+
+// WriteText 변경 내역을 터미널에 출력하기 위한 텍스트 형식으로 출력한다.
+func WriteText(w io.Writer, changes []Change) error {
+	for _, c := range changes {
+		l := c.Lecture()
+
+		mark := map[ChangeType]string{ChangeAdded: "+", ChangeRemoved: "-", ChangeChanged: "*"}[c.Type]
+		if _, err := fmt.Fprintf(w, "%s [%s] %s | %s | %s %s~%s | %s\n", mark, c.Type, l.StoreName, l.Title, l.DayOfTheWeek, l.StartTime, l.EndTime, l.Price); err != nil {
+			return err
+		}
+		for _, f := range c.Changes {
+			if _, err := fmt.Fprintf(w, "    %s : %s → %s\n", f.Field, f.Old, f.New); err != nil {
+				return err
+			}
+		}
+	}
+
+	added, removed, changed := Count(changes)
+	_, err := fmt.Fprintf(w, "추가 %d건, 삭제 %d건, 변경 %d건\n", added, removed, changed)
+
+	return err
+}
+
+// WriteMarkdown 변경 내역을 Markdown 형식으로 출력한다.
+func WriteMarkdown(w io.Writer, changes []Change) error {
+	var sb strings.Builder
+
+	added, removed, changed := Count(changes)
+	sb.WriteString("# 문화센터 강좌 변경 내역\n\n")
+	sb.WriteString(fmt.Sprintf("추가 %d건, 삭제 %d건, 변경 %d건\n", added, removed, changed))
+
+	for _, t := range []ChangeType{ChangeAdded, ChangeRemoved, ChangeChanged} {
+		var section []Change
+		for _, c := range changes {
+			if c.Type == t {
+				section = append(section, c)
+			}
+		}
+		if len(section) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("\n## %s된 강좌 (%d건)\n\n", t, len(section)))
+		sb.WriteString("| 점포 | 강좌명 | 강의시간 | 수강료 | 접수상태 | 변경 내역 |\n")
+		sb.WriteString("|------|--------|----------|--------|----------|-----------|\n")
+		for _, c := range section {
+			l := c.Lecture()
+
+			var fieldChanges []string
+			for _, f := range c.Changes {
+				fieldChanges = append(fieldChanges, fmt.Sprintf("%s: %s → %s", f.Field, markdownEscape(f.Old), markdownEscape(f.New)))
+			}
+
+			title := markdownEscape(l.Title)
+			if l.DetailPageUrl != "" {
+				title = fmt.Sprintf("[%s](%s)", title, l.DetailPageUrl)
+			}
+
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s %s~%s | %s | %s | %s |\n", markdownEscape(l.StoreName), title, l.DayOfTheWeek, l.StartTime, l.EndTime, l.Price, l.Status, strings.Join(fieldChanges, "<br>")))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// WriteJSON 변경 내역을 JSON 형식으로 출력한다.
+func WriteJSON(w io.Writer, changes []Change) error {
+	if changes == nil {
+		changes = []Change{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(changes)
+}
+
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
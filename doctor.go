@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"os"
+)
+
+// doctorCommand 문화센터 체인별로 사이트 구조 점검을 실행하고 기대값과 실제값의 차이를 출력한다.
+// 하나라도 점검에 실패하면 0이 아닌 종료코드로 종료한다.
+func doctorCommand(cfg *config.Config) {
+	failedCount := 0
+
+	s := scrape.New(cfg)
+	for _, scraper := range s.Scrapers(searchYear, searchSeason) {
+		fmt.Printf("[%s]\n", scraper.Name())
+
+		for _, check := range scraper.Doctor() {
+			if check.Passed == true {
+				fmt.Printf("  [정상] %s\n", check.Name)
+				continue
+			}
+
+			failedCount++
+
+			found := check.Found
+			if found == "" {
+				found = "(없음)"
+			}
+
+			fmt.Printf("  [실패] %s\n", check.Name)
+			fmt.Printf("         - 기대값: %s\n", check.Expected)
+			fmt.Printf("         + 실제값: %s\n", found)
+		}
+
+		fmt.Println("")
+	}
+
+	if failedCount > 0 {
+		fmt.Printf("사이트 구조 점검 결과 %d건의 문제가 발견되었습니다.\n", failedCount)
+		os.Exit(1)
+	}
+
+	fmt.Println("사이트 구조 점검 결과 문제가 발견되지 않았습니다.")
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"strings"
+)
+
+// explainCommand 강좌에 필터링 규칙 및 수강자별 선호 조건을 적용한 결과를 규칙별로 출력한다.
+// 강좌가 왜 제외되었는지(또는 왜 제외되지 않았는지) 확인하여 필터링 규칙을 조정할 때 사용한다.
+func explainCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	runID := fs.Uint64("run", 0, "강좌 목록을 읽어들일 스냅샷 저장소의 실행 ID(0이면 검색년도 및 검색시즌이 같은 가장 최근의 실행)")
+	fileName := fs.String("file", "", "스냅샷 저장소 대신 강좌 목록을 읽어들일 CSV 또는 JSON 파일")
+	where := fs.String("where", "", "강좌가 만족해야 하는 조건식(예:day in [토,일] && price <= 50000)")
+	utils.CheckErr(fs.Parse(args))
+
+	if fs.NArg() != 1 {
+		log.Fatalf("설명할 강좌 ID를 하나 지정하세요(예:explain emart/560/12345)")
+	}
+	id := fs.Arg(0)
+
+	whereExpr := parseWhere(*where)
+
+	var lectureList []lectures.Lecture
+	if *fileName != "" {
+		var err error
+		lectureList, err = scrape.ReadFile(*fileName)
+		utils.CheckErr(err)
+	} else {
+		if cfg.Snapshot.Enabled == false {
+			log.Fatalf("스냅샷 저장소를 사용하지 않도록 설정되어 있습니다(-file 옵션으로 강좌 목록 파일을 지정하세요)")
+		}
+		lectureList = snapshotLectures(cfg, *runID, "-file 옵션으로 강좌 목록 파일을 지정하세요")
+	}
+
+	var lecture *lectures.Lecture
+	for i := range lectureList {
+		if lectureList[i].ID == id {
+			lecture = &lectureList[i]
+			break
+		}
+	}
+	if lecture == nil {
+		log.Fatalf("강좌가 존재하지 않습니다(강좌 ID:%s)", id)
+	}
+
+	fmt.Printf("%s\n", lecture.ID)
+	fmt.Printf("  %s | %s | %s %s~%s | 개강일 %s | %s | %s\n", lecture.StoreName, lecture.Title, lecture.DayOfTheWeek, lecture.StartTime, lecture.EndTime, lecture.StartDate, lecture.Price, lecture.Status)
+	if lecture.TargetAge != "" {
+		fmt.Printf("  수강대상 : %s\n", lecture.TargetAge)
+	}
+
+	scrape.Locate(cfg.Geo, lecture)
+	if lecture.Distance > 0 {
+		approx := ""
+		if lecture.DistanceApprox == true {
+			approx = ", 시, 군 위치로 추정"
+		}
+		fmt.Printf("  거리 : %.1fkm(이동시간 약 %d분%s)\n", lecture.Distance, lecture.TravelMinutes, approx)
+	}
+
+	holidays := cfg.HolidayCalendar()
+	learners := learnerProfiles(cfg)
+
+	s := scrape.New(cfg)
+	for _, lc := range learners {
+		fmt.Printf("\n[수강자 %s(%s생)]\n", lc.Name, lc.Birthday)
+
+		for _, r := range s.ExplainLearner(lecture, lc, holidays) {
+			mark := "✔"
+			if r.Excluded == true {
+				mark = "✘"
+			}
+			line := fmt.Sprintf("  %s %s(%s)", mark, r.Rule, r.Type)
+			if r.Excluded == true && r.Detail != "" {
+				line += " : " + r.Detail
+			}
+			if r.Disabled == true {
+				line += " - 사용하지 않는 규칙"
+			}
+			fmt.Println(line)
+		}
+	}
+
+	// 전체 필터링 과정을 그대로 적용하여 최종 결과를 출력한다.
+	s.SetLectures([]lectures.Lecture{*lecture})
+	s.FilterLearners(learners, holidays)
+	if whereExpr != nil {
+		s.Where(whereExpr)
+	}
+	result := s.Lectures()[0]
+
+	fmt.Println()
+	if result.ScrapeExcluded == false {
+		fmt.Printf("결과 : 수강 가능(%s)\n", strings.Join(result.Learners, ", "))
+	} else {
+		fmt.Printf("결과 : 제외(%s)\n", result.ExcludedBy)
+	}
+	for _, r := range result.ExcludedReasons {
+		fmt.Printf("  - %s\n", r)
+	}
+}
@@ -0,0 +1,121 @@
+package expr
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"regexp"
+	"strings"
+)
+
+// node 조건식의 구문 트리 노드
+type node interface {
+	eval(lecture *lectures.Lecture) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n *andNode) eval(lecture *lectures.Lecture) bool {
+	return n.left.eval(lecture) == true && n.right.eval(lecture) == true
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n *orNode) eval(lecture *lectures.Lecture) bool {
+	return n.left.eval(lecture) == true || n.right.eval(lecture) == true
+}
+
+type notNode struct {
+	node node
+}
+
+func (n *notNode) eval(lecture *lectures.Lecture) bool {
+	return n.node.eval(lecture) == false
+}
+
+// value 비교할 값
+type value struct {
+	text   string
+	number float64
+	status lectures.ReceptionStatus
+	re     *regexp.Regexp
+}
+
+// compareNode 항목 비교(in 연산자는 값 목록 중 하나라도 같으면 참이다)
+type compareNode struct {
+	field  *field
+	op     string
+	values []value
+}
+
+func (n *compareNode) eval(lecture *lectures.Lecture) bool {
+	switch n.op {
+	case "in":
+		for _, v := range n.values {
+			if n.equal(lecture, v) == true {
+				return true
+			}
+		}
+		return false
+	case "==":
+		return n.equal(lecture, n.values[0])
+	case "!=":
+		return n.equal(lecture, n.values[0]) == false
+	case "~":
+		return n.values[0].re.MatchString(n.field.text(lecture))
+	case "!~":
+		return n.values[0].re.MatchString(n.field.text(lecture)) == false
+	case "contains":
+		return strings.Contains(n.field.text(lecture), n.values[0].text)
+	}
+
+	// 값이 없는 항목(예:수강료를 알 수 없는 강좌)은 크기 비교를 만족하지 않는다.
+	var c int
+	v := n.values[0]
+	if n.field.kind == kindNumber {
+		number, ok := n.field.number(lecture)
+		if ok == false {
+			return false
+		}
+		switch {
+		case number < v.number:
+			c = -1
+		case number > v.number:
+			c = 1
+		}
+	} else {
+		text := n.field.text(lecture)
+		if text == "" {
+			return false
+		}
+		c = strings.Compare(text, v.text)
+	}
+
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func (n *compareNode) equal(lecture *lectures.Lecture, v value) bool {
+	switch n.field.kind {
+	case kindNumber:
+		number, ok := n.field.number(lecture)
+		return ok == true && number == v.number
+	case kindStatus:
+		return lecture.Status == v.status
+	case kindDay:
+		// 요일은 '토' 또는 '토요일' 형식으로 수집되므로 첫 글자만 비교한다.
+		return strings.HasPrefix(n.field.text(lecture), v.text)
+	}
+	return n.field.text(lecture) == v.text
+}
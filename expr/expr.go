@@ -0,0 +1,169 @@
+// Package expr 강좌를 조회할 때 사용하는 조건식을 해석하고 평가한다.
+//
+// 조건식은 항목 비교를 &&(and), ||(or), !(not) 및 괄호로 조합한다(예:day in [토,일] && start >= 10:00 && price <= 50000 && title ~ "미술|요리").
+// 비교할 값에 공백이나 연산자 문자가 포함되면 따옴표로 감싼다.
+package expr
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"sort"
+	"strings"
+)
+
+// Expr 해석된 조건식
+type Expr struct {
+	src  string
+	root node
+}
+
+// Parse 조건식을 해석한다. 조건식이 올바르지 않으면 오류가 발생한 위치가 포함된 *SyntaxError를 반환한다.
+func Parse(src string) (*Expr, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{src: src, tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, p.errorAt(p.peek(), "조건식이 비어 있습니다")
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorAt(t, "'&&' 또는 '||'가 필요합니다('%s')", t.text)
+	}
+
+	return &Expr{src: src, root: root}, nil
+}
+
+// Match 강좌가 조건식을 만족하는지의 여부를 반환한다.
+func (e *Expr) Match(lecture *lectures.Lecture) bool {
+	return e.root.eval(lecture)
+}
+
+func (e *Expr) String() string {
+	return e.src
+}
+
+// SyntaxError 조건식의 구문 오류
+type SyntaxError struct {
+	Source  string // 조건식
+	Column  int    // 오류가 발생한 위치(1부터 시작하는 문자 단위)
+	Message string // 오류 내용
+}
+
+func newSyntaxError(src string, column int, format string, a ...any) *SyntaxError {
+	return &SyntaxError{Source: src, Column: column, Message: fmt.Sprintf(format, a...)}
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("조건식의 %d번째 문자에서 오류가 발생하였습니다: %s", e.Column, e.Message)
+}
+
+// Pointer 조건식과 오류가 발생한 위치를 가리키는 표시(^)를 두 줄로 반환한다.
+func (e *SyntaxError) Pointer() string {
+	// 한글 등 전각 문자는 터미널에서 두 칸을 차지하므로 표시 위치를 맞춘다.
+	width := 0
+	for i, r := range []rune(e.Source) {
+		if i >= e.Column-1 {
+			break
+		}
+		if r >= 0x1100 && (r <= 0x115F || (r >= 0x2E80 && r <= 0xA4CF) || (r >= 0xAC00 && r <= 0xD7A3) || (r >= 0xF900 && r <= 0xFAFF) || (r >= 0xFF00 && r <= 0xFF60)) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return e.Source + "\n" + strings.Repeat(" ", width) + "^"
+}
+
+// fieldKind 항목의 값 종류
+type fieldKind int
+
+const (
+	kindString   fieldKind = iota // 문자열
+	kindNumber                    // 숫자
+	kindTime                      // 시간(hh:mm)
+	kindDate                      // 날짜(YYYY-MM-DD)
+	kindDay                       // 요일
+	kindStatus                    // 접수상태
+	kindCategory                  // 활동 분류
+)
+
+// supports 항목의 값 종류에 비교 연산자를 사용할 수 있는지의 여부를 반환한다.
+func (k fieldKind) supports(op string) bool {
+	switch op {
+	case "==", "!=", "in":
+		return true
+	case "<", "<=", ">", ">=":
+		return k == kindNumber || k == kindTime || k == kindDate
+	case "~", "!~", "contains":
+		return k == kindString
+	}
+	return false
+}
+
+// field 조건식에서 사용 가능한 강좌 항목
+type field struct {
+	name   string
+	kind   fieldKind
+	text   func(l *lectures.Lecture) string          // 문자열, 시간, 날짜, 요일 항목의 값
+	number func(l *lectures.Lecture) (float64, bool) // 숫자 항목의 값(값이 없으면 false)
+}
+
+// fields 조건식에서 사용 가능한 항목 목록
+var fields = map[string]*field{}
+
+func init() {
+	text := func(name string, kind fieldKind, f func(l *lectures.Lecture) string) {
+		fields[name] = &field{name: name, kind: kind, text: f}
+	}
+	text("id", kindString, func(l *lectures.Lecture) string { return l.ID })
+	text("chain", kindString, func(l *lectures.Lecture) string { return l.Chain })
+	text("store", kindString, func(l *lectures.Lecture) string { return l.StoreName })
+	text("group", kindString, func(l *lectures.Lecture) string { return l.Group })
+	text("title", kindString, func(l *lectures.Lecture) string { return l.Title })
+	text("program", kindString, func(l *lectures.Lecture) string { return l.Program })
+	text("teacher", kindString, func(l *lectures.Lecture) string { return l.Teacher })
+	text("target", kindString, func(l *lectures.Lecture) string { return l.TargetAge })
+	text("category", kindCategory, func(l *lectures.Lecture) string { return string(l.Category) })
+	text("day", kindDay, func(l *lectures.Lecture) string { return l.DayOfTheWeek })
+	text("start", kindTime, func(l *lectures.Lecture) string { return l.StartTime })
+	text("end", kindTime, func(l *lectures.Lecture) string { return l.EndTime })
+	text("startDate", kindDate, func(l *lectures.Lecture) string { return l.StartDate })
+	text("endDate", kindDate, func(l *lectures.Lecture) string { return l.EndDate })
+
+	fields["price"] = &field{name: "price", kind: kindNumber, number: func(l *lectures.Lecture) (float64, bool) {
+		price, ok := l.PriceValue()
+		return float64(price), ok
+	}}
+	fields["sessionFee"] = &field{name: "sessionFee", kind: kindNumber, number: func(l *lectures.Lecture) (float64, bool) {
+		sessionFee, ok := l.SessionFeeValue()
+		return float64(sessionFee), ok
+	}}
+	fields["discount"] = &field{name: "discount", kind: kindNumber, number: func(l *lectures.Lecture) (float64, bool) {
+		return float64(l.Discount), l.OriginalFee > 0
+	}}
+	fields["distance"] = &field{name: "distance", kind: kindNumber, number: func(l *lectures.Lecture) (float64, bool) {
+		return l.Distance, l.Distance > 0
+	}}
+	fields["count"] = &field{name: "count", kind: kindNumber, number: func(l *lectures.Lecture) (float64, bool) {
+		count := l.SessionCount()
+		return float64(count), count > 0
+	}}
+	fields["status"] = &field{name: "status", kind: kindStatus}
+}
+
+// FieldNames 조건식에서 사용 가능한 항목 이름 목록을 반환한다.
+func FieldNames() []string {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,184 @@
+package expr
+
+import (
+	"errors"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"strings"
+	"testing"
+)
+
+// testLecture 조건식 평가에 사용할 강좌를 반환한다.
+func testLecture() lectures.Lecture {
+	return lectures.Lecture{
+		ID:           "emart/560/12345",
+		Chain:        "emart",
+		StoreName:    "이마트 여수점",
+		Group:        "유아",
+		Title:        "토요 미술놀이",
+		Teacher:      "김 선생",
+		DayOfTheWeek: "토요일",
+		StartTime:    "10:30",
+		EndTime:      "11:20",
+		StartDate:    "2025-03-08",
+		EndDate:      "2025-05-31",
+		Count:        "12회",
+		Fee:          60000,
+		Status:       lectures.ReceptionStatusPossible,
+		Category:     lectures.CategoryArt,
+		Distance:     12.5,
+	}
+}
+
+func TestParseValid(t *testing.T) {
+	tests := []string{
+		`day in [토,일] && start >= 10:00 && price <= 50000 && title ~ "미술|요리"`,
+		`day in [토요일, 일요일]`,
+		`(chain == emart || chain == lottemart) and not status == 접수마감`,
+		`!(title contains 발레)`,
+		`store == "이마트 여수점"`,
+		`title == "say \"hi\""`,
+		`price < "10,000"`,
+		`startDate >= 2025-03-01 && endDate < 2025-06-01`,
+		`category in [art, 음악]`,
+		`sessionFee <= 15000 || discount > 0 || distance <= 20 || count >= 10`,
+		`title !~ "^\\[광주"`,
+		`title CONTAINS 미술 AND day IN [토]`,
+	}
+
+	for _, src := range tests {
+		e, err := Parse(src)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", src, err)
+			continue
+		}
+		if e.String() != src {
+			t.Errorf("String() = %q, want %q", e.String(), src)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	lecture := testLecture()
+
+	noDistance := testLecture()
+	noDistance.Distance = 0
+
+	noPrice := testLecture()
+	noPrice.Fee = 0
+	noPrice.Price = "문의"
+
+	tests := []struct {
+		src     string
+		lecture *lectures.Lecture
+		want    bool
+	}{
+		{`day in [토,일] && start >= 10:00 && price <= 60000 && title ~ "미술|요리"`, &lecture, true},
+		{`day == 일`, &lecture, false},
+		{`day == 토요일`, &lecture, true},
+		{`day != 토`, &lecture, false},
+		{`price < 60000`, &lecture, false},
+		{`price == "60,000"`, &lecture, true},
+		{`price <= 100000`, &noPrice, false},
+		{`price > 0`, &noPrice, false},
+		{`sessionFee == 5000`, &lecture, true},
+		{`!(store contains 여수)`, &lecture, false},
+		{`store == "이마트 여수점"`, &lecture, true},
+		{`status == 접수가능`, &lecture, true},
+		{`status in [접수마감, 대기신청]`, &lecture, false},
+		{`category == art`, &lecture, true},
+		{`category == music`, &lecture, false},
+		{`count >= 10 && count < 13`, &lecture, true},
+		{`distance <= 20`, &lecture, true},
+		{`distance <= 20`, &noDistance, false},
+		{`discount > 0`, &lecture, false},
+		{`startDate >= 2025-03-01 and endDate < 2025-06-01`, &lecture, true},
+		{`end <= 11:00`, &lecture, false},
+		{`chain == lottemart or teacher == "김 선생"`, &lecture, true},
+		{`chain == lottemart || teacher == 김`, &lecture, false},
+		{`not title !~ 미술`, &lecture, true},
+		{`id == emart/560/12345`, &lecture, true},
+		{`group contains 유아 && !(chain == emart)`, &lecture, false},
+	}
+
+	for _, tt := range tests {
+		e, err := Parse(tt.src)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.src, err)
+			continue
+		}
+		if got := e.Match(tt.lecture); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	tests := []struct {
+		src     string
+		column  int    // 오류가 발생한 위치
+		message string // 오류 내용에 포함된 문자열
+	}{
+		{``, 1, "조건식이 비어 있습니다"},
+		{`titl == 발레`, 1, "알 수 없는 항목입니다(titl"},
+		{`price >= 1 && dae == 토`, 15, "알 수 없는 항목입니다(dae"},
+		{`day in [토, 일`, 13, "',' 또는 ']'가 필요합니다"},
+		{`day in [토 일]`, 11, "',' 또는 ']'가 필요합니다"},
+		{`day in 토`, 8, "'in' 다음에 '['가 필요합니다"},
+		{`title ~ "[미술"`, 9, "정규표현식이 올바르지 않습니다"},
+		{`title contains "발레`, 16, "따옴표가 닫히지 않았습니다"},
+		{`TITLE contains 미술`, 1, "알 수 없는 항목입니다(TITLE"},
+		{`제목 == "발레"`, 1, "알 수 없는 항목입니다(제목"},
+		{`store == 여수점 && 요일 == 토`, 17, "알 수 없는 항목입니다(요일"},
+		{`day == 토 & start >= 10:00`, 10, "'&' 대신 '&&'를 사용하세요"},
+		{`price ~ "a"`, 7, "'price' 항목에는 '~' 연산자를 사용할 수 없습니다"},
+		{`start >= 25:00`, 10, "시간(hh:mm)과 비교해야 합니다"},
+		{`day == 월화`, 8, "요일(월~일)과 비교해야 합니다"},
+		{`status == 접수중지`, 11, "지원하지 않는 접수상태입니다"},
+		{`(price > 1`, 11, "')'가 필요합니다"},
+		{`price > 1 price < 2`, 11, "'&&' 또는 '||'가 필요합니다"},
+		{`price`, 6, "비교 연산자가 필요합니다"},
+		{`price >`, 8, "비교할 값이 필요합니다"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.src)
+
+		var se *SyntaxError
+		if errors.As(err, &se) == false {
+			t.Errorf("Parse(%q) error = %v, want *SyntaxError", tt.src, err)
+			continue
+		}
+		if se.Column != tt.column {
+			t.Errorf("Parse(%q) Column = %d, want %d\n%s", tt.src, se.Column, tt.column, se.Pointer())
+		}
+		if strings.Contains(se.Message, tt.message) == false {
+			t.Errorf("Parse(%q) Message = %q, want %q", tt.src, se.Message, tt.message)
+		}
+	}
+}
+
+func TestSyntaxErrorPointer(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`price >= 1 && dae == 토`, "price >= 1 && dae == 토\n              ^"},
+		{`day in [토, 일`, "day in [토, 일\n              ^"},
+		// 한글은 두 칸을 차지하므로 오류 위치 앞의 한글 갯수만큼 한 칸씩 더 띄운다.
+		{`store == 여수점 && 요일 == 토`, "store == 여수점 && 요일 == 토\n                   ^"},
+		{`title contains "발레`, "title contains \"발레\n               ^"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.src)
+
+		var se *SyntaxError
+		if errors.As(err, &se) == false {
+			t.Errorf("Parse(%q) error = %v, want *SyntaxError", tt.src, err)
+			continue
+		}
+		if got := se.Pointer(); got != tt.want {
+			t.Errorf("Pointer() =\n%s\nwant\n%s", got, tt.want)
+		}
+	}
+}
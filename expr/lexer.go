@@ -0,0 +1,130 @@
+package expr
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenKind 토큰 종류
+type tokenKind int
+
+const (
+	tokenEOF      tokenKind = iota // 조건식의 끝
+	tokenWord                      // 항목 이름 또는 따옴표로 감싸지 않은 값(예:price, 10:00, 토)
+	tokenString                    // 따옴표로 감싼 문자열
+	tokenAnd                       // &&, and
+	tokenOr                        // ||, or
+	tokenNot                       // !, not
+	tokenOp                        // 비교 연산자(==, !=, <, <=, >, >=, ~, !~, contains, in)
+	tokenLParen                    // (
+	tokenRParen                    // )
+	tokenLBracket                  // [
+	tokenRBracket                  // ]
+	tokenComma                     // ,
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	column int // 조건식에서 토큰이 시작되는 위치(1부터 시작하는 문자 단위)
+}
+
+// 단어에 포함될 수 없는 문자
+const delimiters = "()[],\"!=<>~&|"
+
+// keywords 단어로 표현되는 논리 연산자 및 비교 연산자
+var keywords = map[string]tokenKind{
+	"and":      tokenAnd,
+	"or":       tokenOr,
+	"not":      tokenNot,
+	"in":       tokenOp,
+	"contains": tokenOp,
+}
+
+// tokenize 조건식을 토큰 목록으로 분리한다.
+func tokenize(src string) ([]token, error) {
+	runes := []rune(src)
+
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		column := i + 1
+
+		if unicode.IsSpace(r) == true {
+			i++
+			continue
+		}
+
+		// 두 글자로 이루어진 연산자
+		if i+1 < len(runes) {
+			switch string(runes[i : i+2]) {
+			case "&&":
+				tokens = append(tokens, token{kind: tokenAnd, text: "&&", column: column})
+				i += 2
+				continue
+			case "||":
+				tokens = append(tokens, token{kind: tokenOr, text: "||", column: column})
+				i += 2
+				continue
+			case "==", "!=", "<=", ">=", "!~":
+				tokens = append(tokens, token{kind: tokenOp, text: string(runes[i : i+2]), column: column})
+				i += 2
+				continue
+			}
+		}
+
+		switch r {
+		case '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", column: column})
+		case ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", column: column})
+		case '[':
+			tokens = append(tokens, token{kind: tokenLBracket, text: "[", column: column})
+		case ']':
+			tokens = append(tokens, token{kind: tokenRBracket, text: "]", column: column})
+		case ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", column: column})
+		case '!':
+			tokens = append(tokens, token{kind: tokenNot, text: "!", column: column})
+		case '=':
+			tokens = append(tokens, token{kind: tokenOp, text: "==", column: column})
+		case '<', '>', '~':
+			tokens = append(tokens, token{kind: tokenOp, text: string(r), column: column})
+		case '&', '|':
+			return nil, newSyntaxError(src, column, "'%c' 대신 '%c%c'를 사용하세요", r, r, r)
+
+		case '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, newSyntaxError(src, column, "따옴표가 닫히지 않았습니다")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), column: column})
+			i = j + 1
+			continue
+
+		default:
+			j := i
+			for j < len(runes) && unicode.IsSpace(runes[j]) == false && strings.ContainsRune(delimiters, runes[j]) == false {
+				j++
+			}
+			word := string(runes[i:j])
+			if kind, exists := keywords[strings.ToLower(word)]; exists == true {
+				tokens = append(tokens, token{kind: kind, text: strings.ToLower(word), column: column})
+			} else {
+				tokens = append(tokens, token{kind: tokenWord, text: word, column: column})
+			}
+			i = j
+			continue
+		}
+		i++
+	}
+
+	return append(tokens, token{kind: tokenEOF, column: len(runes) + 1}), nil
+}
@@ -0,0 +1,213 @@
+package expr
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parser 토큰 목록을 구문 트리로 변환한다.
+//
+//	expr       = or
+//	or         = and { ("||" | "or") and }
+//	and        = unary { ("&&" | "and") unary }
+//	unary      = ("!" | "not") unary | "(" expr ")" | comparison
+//	comparison = field op value | field "in" "[" value { "," value } "]"
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorAt(t token, format string, a ...any) error {
+	return newSyntaxError(p.src, t.column, format, a...)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch t := p.peek(); t.kind {
+	case tokenNot:
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{node: n}, nil
+
+	case tokenLParen:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, p.errorAt(t, "')'가 필요합니다")
+		}
+		return n, nil
+
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *parser) parseComparison() (node, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		if t.kind == tokenEOF {
+			return nil, p.errorAt(t, "조건이 필요합니다")
+		}
+		return nil, p.errorAt(t, "항목 이름이 필요합니다('%s')", t.text)
+	}
+	f, exists := fields[t.text]
+	if exists == false {
+		return nil, p.errorAt(t, "알 수 없는 항목입니다(%s, 사용 가능한 항목:%s)", t.text, strings.Join(FieldNames(), ", "))
+	}
+
+	opToken := p.next()
+	if opToken.kind != tokenOp {
+		return nil, p.errorAt(opToken, "'%s' 다음에 비교 연산자가 필요합니다", t.text)
+	}
+	if f.kind.supports(opToken.text) == false {
+		return nil, p.errorAt(opToken, "'%s' 항목에는 '%s' 연산자를 사용할 수 없습니다", t.text, opToken.text)
+	}
+
+	cmp := &compareNode{field: f, op: opToken.text}
+
+	if opToken.text == "in" {
+		if lt := p.next(); lt.kind != tokenLBracket {
+			return nil, p.errorAt(lt, "'in' 다음에 '['가 필요합니다")
+		}
+		for {
+			v, err := p.parseValue(f, opToken.text)
+			if err != nil {
+				return nil, err
+			}
+			cmp.values = append(cmp.values, v)
+
+			sep := p.next()
+			if sep.kind == tokenRBracket {
+				break
+			}
+			if sep.kind != tokenComma {
+				return nil, p.errorAt(sep, "',' 또는 ']'가 필요합니다")
+			}
+		}
+		return cmp, nil
+	}
+
+	v, err := p.parseValue(f, opToken.text)
+	if err != nil {
+		return nil, err
+	}
+	cmp.values = append(cmp.values, v)
+
+	return cmp, nil
+}
+
+// parseValue 비교할 값을 읽어 항목의 종류에 맞게 변환한다.
+func (p *parser) parseValue(f *field, op string) (value, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return value{}, p.errorAt(t, "비교할 값이 필요합니다")
+	}
+
+	v := value{text: t.text}
+
+	if op == "~" || op == "!~" {
+		re, err := regexp.Compile(t.text)
+		if err != nil {
+			return value{}, p.errorAt(t, "정규표현식이 올바르지 않습니다(%s)", err)
+		}
+		v.re = re
+		return v, nil
+	}
+
+	switch f.kind {
+	case kindNumber:
+		n, err := strconv.ParseFloat(strings.ReplaceAll(t.text, ",", ""), 64)
+		if err != nil {
+			return value{}, p.errorAt(t, "'%s' 항목은 숫자와 비교해야 합니다('%s')", f.name, t.text)
+		}
+		v.number = n
+
+	case kindTime:
+		tm, err := time.Parse("15:04", t.text)
+		if err != nil {
+			return value{}, p.errorAt(t, "'%s' 항목은 시간(hh:mm)과 비교해야 합니다('%s')", f.name, t.text)
+		}
+		v.text = tm.Format("15:04")
+
+	case kindDate:
+		if _, err := time.Parse("2006-01-02", t.text); err != nil {
+			return value{}, p.errorAt(t, "'%s' 항목은 날짜(YYYY-MM-DD)와 비교해야 합니다('%s')", f.name, t.text)
+		}
+
+	case kindDay:
+		day := strings.TrimSuffix(t.text, "요일")
+		if len([]rune(day)) != 1 || strings.Contains("월화수목금토일", day) == false {
+			return value{}, p.errorAt(t, "'%s' 항목은 요일(월~일)과 비교해야 합니다('%s')", f.name, t.text)
+		}
+		v.text = day
+
+	case kindCategory:
+		category, ok := lectures.ParseCategory(t.text)
+		if ok == false {
+			return value{}, p.errorAt(t, "지원하지 않는 활동 분류입니다('%s')", t.text)
+		}
+		v.text = string(category)
+
+	case kindStatus:
+		status, ok := lectures.ParseReceptionStatus(t.text)
+		if ok == false {
+			return value{}, p.errorAt(t, "지원하지 않는 접수상태입니다('%s')", t.text)
+		}
+		v.status = status
+	}
+
+	return v, nil
+}
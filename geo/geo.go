@@ -0,0 +1,103 @@
+// Package geo 점포 및 집의 위치로 거리와 이동시간을 계산한다.
+//
+// 인터넷 지도 서비스를 사용하지 않으므로, 거리는 두 지점의 직선거리에 도로 우회 비율을 곱한 추정값이며,
+// 좌표를 알 수 없는 점포는 주소 또는 점포명에 포함된 시, 군의 시청(군청) 위치로 대신한다.
+package geo
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// 지구 반지름(km)
+const earthRadius = 6371.0
+
+// Point 위도 및 경도
+type Point struct {
+	Lat float64 `json:"lat"` // 위도
+	Lng float64 `json:"lng"` // 경도
+}
+
+// Valid 위도 및 경도가 지정되어 있고 올바른 범위인지의 여부를 반환한다.
+func (p Point) Valid() bool {
+	if p.Lat == 0 && p.Lng == 0 {
+		return false
+	}
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// Distance 두 지점의 직선거리(km)를 반환한다.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := (b.Lat - a.Lat) * math.Pi / 180
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Estimate 직선거리로 도로거리와 이동시간을 추정한다.
+// 도로거리는 직선거리에 도로 우회 비율(detourFactor)을 곱하며, 이동시간은 도로거리를 평균 주행 속도(km/h)로 나눈다.
+func Estimate(distance, detourFactor, speed float64) (float64, time.Duration) {
+	road := distance * detourFactor
+	if speed <= 0 {
+		return road, 0
+	}
+	return road, time.Duration(road / speed * float64(time.Hour))
+}
+
+// cities 시, 군별 시청(군청) 위치
+// 좌표를 알 수 없는 점포의 위치를 추정하는 데 사용하는 오프라인 조회표이며, 문화센터 점포가 있는 주요 도시를 포함한다.
+// 주소 및 점포명에서 시, 군 이름 또는 줄여 쓴 이름(aliases)과 정확히 일치하는 단어만 찾는다.
+// 광주광역시와 경기도 광주시처럼 줄여 쓴 이름이 같은 시, 군이 있으므로 '광주시'는 광주광역시의 이름으로 사용하지 않는다.
+var cities = []struct {
+	name    string
+	aliases []string
+	point   Point
+}{
+	{"서울특별시", []string{"서울", "서울시"}, Point{37.5665, 126.9780}},
+	{"부산광역시", []string{"부산", "부산시"}, Point{35.1796, 129.0756}},
+	{"대구광역시", []string{"대구", "대구시"}, Point{35.8714, 128.6014}},
+	{"인천광역시", []string{"인천", "인천시"}, Point{37.4563, 126.7052}},
+	{"광주광역시", []string{"광주"}, Point{35.1595, 126.8526}},
+	{"대전광역시", []string{"대전", "대전시"}, Point{36.3504, 127.3845}},
+	{"울산광역시", []string{"울산", "울산시"}, Point{35.5384, 129.3114}},
+	{"세종특별자치시", []string{"세종", "세종시"}, Point{36.4800, 127.2890}},
+	{"수원시", []string{"수원"}, Point{37.2636, 127.0286}},
+	{"성남시", []string{"성남"}, Point{37.4200, 127.1267}},
+	{"용인시", []string{"용인"}, Point{37.2411, 127.1776}},
+	{"고양시", []string{"고양"}, Point{37.6584, 126.8320}},
+	{"청주시", []string{"청주"}, Point{36.6424, 127.4890}},
+	{"천안시", []string{"천안"}, Point{36.8151, 127.1139}},
+	{"전주시", []string{"전주"}, Point{35.8242, 127.1480}},
+	{"창원시", []string{"창원"}, Point{35.2280, 128.6811}},
+	{"김해시", []string{"김해"}, Point{35.2285, 128.8894}},
+	{"진주시", []string{"진주"}, Point{35.1800, 128.1076}},
+	{"포항시", []string{"포항"}, Point{36.0190, 129.3435}},
+	{"제주시", []string{"제주"}, Point{33.4996, 126.5312}},
+	{"목포시", []string{"목포"}, Point{34.8118, 126.3922}},
+	{"여수시", []string{"여수"}, Point{34.7604, 127.6622}},
+	{"순천시", []string{"순천"}, Point{34.9506, 127.4872}},
+	{"광양시", []string{"광양"}, Point{34.9407, 127.6959}},
+}
+
+// LookupCity 주소 또는 점포명(예:전라남도 여수시 ..., 이마트 여수점)에 포함된 시, 군의 시청(군청) 위치와 시, 군 이름을 반환한다.
+// 주소는 공백 등으로 구분된 단어가 시, 군 이름과 정확히 일치해야 하며(예:경기도 광주시는 광주광역시가 아니다), 점포명은 끝의 '점'을 제외하고 비교한다.
+// 시, 군 이름을 찾을 수 없으면 false를 반환한다.
+func LookupCity(text string) (Point, string, bool) {
+	// 앞쪽에 나오는 시, 군 이름이 우선한다(예:광주광역시 서구 여수로).
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) == true || strings.ContainsRune(",()[]", r) == true
+	})
+	for _, word := range words {
+		for _, c := range cities {
+			if word == c.name || slices.Contains(c.aliases, word) == true || slices.Contains(c.aliases, strings.TrimSuffix(word, "점")) == true {
+				return c.point, c.name, true
+			}
+		}
+	}
+	return Point{}, "", false
+}
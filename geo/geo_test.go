@@ -0,0 +1,55 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLookupCity(t *testing.T) {
+	tests := []struct {
+		text string
+		name string // 찾은 시, 군 이름(빈 문자열이면 찾을 수 없음)
+	}{
+		{"전라남도 순천시 장명로 30", "순천시"},
+		{"전남 여수시 좌수영로 1", "여수시"},
+		{"이마트 여수점", "여수시"},
+		{"롯데마트 광주점", "광주광역시"},
+		{"광주광역시 서구 여수로 10", "광주광역시"},
+		{"광주 광산구", "광주광역시"},
+		{"경기도 광주시 오포읍", ""},
+		{"서울시 중구 세종대로 110", "서울특별시"},
+		{"이마트 죽전점", ""},
+		{"여수산단로 1", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		_, name, ok := LookupCity(tt.text)
+		if ok != (tt.name != "") || name != tt.name {
+			t.Errorf("LookupCity(%q) = %q, %v, want %q", tt.text, name, ok, tt.name)
+		}
+	}
+}
+
+func TestDistanceAndEstimate(t *testing.T) {
+	yeosu, _, _ := LookupCity("여수시")
+	suncheon, _, _ := LookupCity("순천시")
+
+	// 여수시청과 순천시청의 직선거리는 약 26km이다.
+	d := Distance(yeosu, suncheon)
+	if math.Abs(d-26) > 1 {
+		t.Errorf("Distance() = %.1f, want 약 26", d)
+	}
+	if Distance(yeosu, yeosu) != 0 {
+		t.Errorf("같은 위치의 Distance() = %f, want 0", Distance(yeosu, yeosu))
+	}
+
+	road, travelTime := Estimate(20, 1.5, 60)
+	if road != 30 || travelTime != 30*time.Minute {
+		t.Errorf("Estimate(20, 1.5, 60) = %v, %v, want 30, 30m", road, travelTime)
+	}
+	if _, travelTime = Estimate(20, 1.5, 0); travelTime != 0 {
+		t.Errorf("속도를 알 수 없으면 이동시간은 0이어야 합니다(%v)", travelTime)
+	}
+}
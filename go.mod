@@ -1,5 +1,14 @@
 module github.com/darkkaiser/culturelecture-scrape
 
-go 1.14
+go 1.22
 
-require github.com/PuerkitoBio/goquery v1.9.2
+require (
+	github.com/PuerkitoBio/goquery v1.9.2
+	go.etcd.io/bbolt v1.3.11
+)
+
+require (
+	github.com/andybalholm/cascadia v1.3.2 // indirect
+	golang.org/x/net v0.24.0 // indirect
+	golang.org/x/sys v0.19.0 // indirect
+)
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"strings"
+)
+
+// groupsCommand 문화센터 체인별 강좌군 트리를 출력한다.
+// 출력된 강좌군명 또는 연령대를 설정 파일의 'groups' 항목에 지정하여 수집할 강좌군을 선택할 수 있다.
+func groupsCommand(cfg *config.Config) {
+	s := scrape.New(cfg)
+	for _, scraper := range s.Scrapers(searchYear, searchSeason) {
+		fmt.Printf("[%s]\n", scraper.Name())
+
+		groups, err := scraper.CultureLectureGroups()
+		utils.CheckErr(err)
+
+		category := ""
+		for i, g := range groups {
+			if i == 0 || g.Category != category {
+				category = g.Category
+				fmt.Printf("  %s\n", category)
+			}
+
+			var ageBuckets []string
+			for _, bucket := range g.AgeBuckets {
+				ageBuckets = append(ageBuckets, string(bucket))
+			}
+
+			if len(ageBuckets) > 0 {
+				fmt.Printf("    %-12s %s (연령대:%s)\n", g.Code, g.Name, strings.Join(ageBuckets, ", "))
+			} else {
+				fmt.Printf("    %-12s %s\n", g.Code, g.Name)
+			}
+		}
+
+		fmt.Println("")
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"strings"
+	"time"
+)
+
+// saveSnapshot 수집된 강좌 목록을 스냅샷 저장소에 새로운 실행으로 저장한다.
+// chains는 일부 체인만 수집한 경우의 체인 ID 목록이며, 전체 체인을 수집한 경우는 nil이다.
+func saveSnapshot(cfg *config.Config, now time.Time, chains []string, lectureList []lectures.Lecture) error {
+	store, err := snapshot.Open(cfg.Snapshot.Path)
+	if err != nil {
+		return err
+	}
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer store.Close()
+
+	run := &snapshot.Run{
+		Time:         now,
+		SearchYear:   searchYear,
+		SearchSeason: searchSeason,
+		Chains:       chains,
+	}
+	if err = store.Save(run, lectureList); err != nil {
+		return err
+	}
+
+	log.Printf("수집된 문화센터 강좌 자료(%d건)를 스냅샷 저장소(%s)에 저장하였습니다.(실행 ID:%d)", run.LectureCount, cfg.Snapshot.Path, run.ID)
+
+	return nil
+}
+
+// historyCommand 스냅샷 저장소에 저장된 수집 이력을 조회한다.
+// 조건을 지정하지 않으면 저장된 실행 목록을 출력한다.
+func historyCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	year := fs.String("year", "", "검색년도(예:2025)")
+	season := fs.String("season", "", "검색시즌(봄, 여름, 가을, 겨울)")
+	storeName := fs.String("store", "", "점포(예:롯데마트 여수점)")
+	title := fs.String("title", "", "강좌명에 포함된 문자열")
+	utils.CheckErr(fs.Parse(args))
+
+	store, err := snapshot.Open(cfg.Snapshot.Path)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer store.Close()
+
+	if *year == "" && *season == "" && *storeName == "" && *title == "" {
+		runs, err := store.Runs()
+		utils.CheckErr(err)
+
+		for _, run := range runs {
+			scope := ""
+			if run.Partial() == true {
+				scope = fmt.Sprintf("  (일부 체인:%s)", strings.Join(run.Chains, ", "))
+			}
+			fmt.Printf("%5d  %s  %s년 %s  %d건%s\n", run.ID, run.Time.Format("2006-01-02 15:04:05"), run.SearchYear, run.SearchSeason, run.LectureCount, scope)
+		}
+		return
+	}
+
+	records, err := store.History(snapshot.Query{
+		SearchYear:   *year,
+		SearchSeason: *season,
+		StoreName:    *storeName,
+		Title:        *title,
+	})
+	utils.CheckErr(err)
+
+	for _, record := range records {
+		lecture := record.Lecture
+		fmt.Printf("%s | %s | %s | %s %s~%s | %s | %s | 수집:%s~%s\n", lecture.StoreName, lecture.Title, lecture.Teacher, lecture.DayOfTheWeek, lecture.StartTime, lecture.EndTime, lecture.Price, lecture.Status, record.FirstSeen.Format("2006-01-02"), record.LastSeen.Format("2006-01-02"))
+	}
+
+	fmt.Printf("총 %d개의 강좌가 조회되었습니다.\n", len(records))
+}
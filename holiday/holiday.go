@@ -0,0 +1,221 @@
+package holiday
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Holiday 공휴일
+type Holiday struct {
+	Date       string `json:"date"`       // 날짜(YYYY-MM-DD)
+	Name       string `json:"name"`       // 공휴일명
+	Substitute bool   `json:"substitute"` // 대체공휴일인지의 여부
+}
+
+// Calendar 연도별 공휴일 달력
+// 연도별 공휴일은 처음 조회될 때 계산되며, 임시공휴일(예:선거일)을 추가할 수 있다.
+type Calendar struct {
+	extra []Holiday
+
+	mu    sync.Mutex
+	years map[int]map[string]Holiday
+}
+
+// NewCalendar 공휴일 달력을 생성한다. extra에는 계산되지 않는 임시공휴일(예:선거일)을 지정한다.
+func NewCalendar(extra []Holiday) *Calendar {
+	return &Calendar{
+		extra: extra,
+		years: make(map[int]map[string]Holiday),
+	}
+}
+
+// Year 지정된 연도의 공휴일 목록을 날짜순으로 반환한다.
+func (c *Calendar) Year(year int) []Holiday {
+	var holidays []Holiday
+	for _, h := range c.year(year) {
+		holidays = append(holidays, h)
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date < holidays[j].Date
+	})
+	return holidays
+}
+
+// Lookup 지정된 날짜의 공휴일을 반환한다. 공휴일이 아니면 false를 반환한다.
+func (c *Calendar) Lookup(t time.Time) (Holiday, bool) {
+	h, ok := c.year(t.Year())[t.Format("2006-01-02")]
+	return h, ok
+}
+
+// IsHoliday 지정된 날짜가 공휴일인지의 여부를 반환한다. 공휴일 달력이 nil이면 항상 false를 반환한다.
+// 음력 공휴일을 계산할 수 없는 연도는 공휴일 여부를 잘못 판단하게 되므로 실행을 중단한다.
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	if c == nil {
+		return false
+	}
+	if LunarCovered(t.Year()) == false {
+		log.Fatalf("%d년은 음력 공휴일(설날, 부처님오신날, 추석)을 계산할 수 있는 연도(%d~%d년)가 아니므로 %s의 공휴일 여부를 판단할 수 없습니다.", t.Year(), lunarFirstYear, lunarLastYear, t.Format("2006-01-02"))
+	}
+	_, ok := c.Lookup(t)
+	return ok
+}
+
+func (c *Calendar) year(year int) map[string]Holiday {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if holidays, exists := c.years[year]; exists == true {
+		return holidays
+	}
+
+	holidays := make(map[string]Holiday)
+	for _, h := range Compute(year) {
+		holidays[h.Date] = h
+	}
+	for _, h := range c.extra {
+		if strings.HasPrefix(h.Date, fmt.Sprintf("%04d-", year)) == true {
+			if _, exists := holidays[h.Date]; exists == false {
+				holidays[h.Date] = h
+			}
+		}
+	}
+	c.years[year] = holidays
+
+	return holidays
+}
+
+// 대체공휴일 적용 방식
+type substituteRule int
+
+const (
+	substituteNone             substituteRule = iota // 대체공휴일을 적용하지 않는다.
+	substituteSundayOrOverlap                        // 일요일이거나 다른 공휴일과 겹치면 대체공휴일을 적용한다(설날, 추석).
+	substituteWeekendOrOverlap                       // 토요일, 일요일이거나 다른 공휴일과 겹치면 대체공휴일을 적용한다.
+)
+
+type day struct {
+	date time.Time
+	name string
+	rule substituteRule
+}
+
+// Compute 관공서의 공휴일에 관한 규정에 따라 지정된 연도의 공휴일(대체공휴일 포함)을 계산한다.
+// 음력 공휴일(설날, 부처님오신날, 추석)은 음력을 계산할 수 있는 연도(LunarYears 참고)만 계산된다.
+func Compute(year int) []Holiday {
+	// 대체공휴일 제도는 어린이날은 2014년부터, 국경일은 2021년부터, 부처님오신날 및 기독탄신일은 2023년부터 적용된다.
+	since := func(y int) substituteRule {
+		if year >= y {
+			return substituteWeekendOrOverlap
+		}
+		return substituteNone
+	}
+
+	var days []day
+	solar := func(month time.Month, d int, name string, rule substituteRule) {
+		days = append(days, day{date: solarDate(year, month, d), name: name, rule: rule})
+	}
+	solar(time.January, 1, "신정", substituteNone)
+	solar(time.March, 1, "삼일절", since(2021))
+	solar(time.May, 5, "어린이날", since(2014))
+	solar(time.June, 6, "현충일", substituteNone)
+	solar(time.August, 15, "광복절", since(2021))
+	solar(time.October, 3, "개천절", since(2021))
+	solar(time.October, 9, "한글날", since(2021))
+	solar(time.December, 25, "기독탄신일", since(2023))
+
+	var seollal, chuseok []day
+	if lh, ok := computeLunarHoliday(year); ok == true {
+		for i, name := range []string{"설날 전날", "설날", "설날 다음날"} {
+			seollal = append(seollal, day{date: lh.seollal.AddDate(0, 0, i-1), name: name, rule: substituteSundayOrOverlap})
+		}
+		for i, name := range []string{"추석 전날", "추석", "추석 다음날"} {
+			chuseok = append(chuseok, day{date: lh.chuseok.AddDate(0, 0, i-1), name: name, rule: substituteSundayOrOverlap})
+		}
+		days = append(days, seollal...)
+		days = append(days, chuseok...)
+		days = append(days, day{date: lh.buddha, name: "부처님오신날", rule: since(2023)})
+	} else {
+		log.Printf("%d년은 음력 공휴일(설날, 부처님오신날, 추석)을 계산할 수 있는 연도(%d~%d년)가 아니므로 음력 공휴일은 제외됩니다.", year, lunarFirstYear, lunarLastYear)
+	}
+
+	holidays := make(map[string]Holiday)
+	count := make(map[string]int)
+	for _, d := range days {
+		date := d.date.Format("2006-01-02")
+		count[date]++
+		if h, exists := holidays[date]; exists == true {
+			h.Name += ", " + d.name
+			holidays[date] = h
+		} else {
+			holidays[date] = Holiday{Date: date, Name: d.name}
+		}
+	}
+
+	isHoliday := func(t time.Time) bool {
+		_, exists := holidays[t.Format("2006-01-02")]
+		return exists
+	}
+
+	// substitute 지정된 날짜 이후의 첫 번째 공휴일이 아닌 날을 대체공휴일로 지정한다.
+	substitute := func(after time.Time, name string, skipSaturday bool) {
+		t := after.AddDate(0, 0, 1)
+		for isHoliday(t) == true || t.Weekday() == time.Sunday || (skipSaturday == true && t.Weekday() == time.Saturday) {
+			t = t.AddDate(0, 0, 1)
+		}
+		date := t.Format("2006-01-02")
+		holidays[date] = Holiday{Date: date, Name: "대체공휴일(" + name + ")", Substitute: true}
+	}
+
+	// 설날, 추석 연휴가 일요일 또는 다른 공휴일과 겹치면 연휴 다음의 첫 번째 비공휴일을 대체공휴일로 한다.
+	for _, period := range [][]day{seollal, chuseok} {
+		for _, d := range period {
+			date := d.date.Format("2006-01-02")
+			if d.date.Weekday() == time.Sunday || count[date] > 1 {
+				substitute(period[len(period)-1].date, period[1].name, false)
+			}
+		}
+	}
+
+	// 그 밖의 공휴일이 토요일, 일요일 또는 다른 공휴일과 겹치면 다음의 첫 번째 비공휴일을 대체공휴일로 한다.
+	// 설날, 추석 연휴와 겹치는 경우는 설날, 추석 연휴의 대체공휴일로 처리되었으므로 제외한다.
+	inPeriod := func(t time.Time) bool {
+		for _, period := range [][]day{seollal, chuseok} {
+			for _, d := range period {
+				if d.date.Equal(t) == true {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].date.Before(days[j].date)
+	})
+	overlapped := make(map[string]bool)
+	for _, d := range days {
+		if d.rule != substituteWeekendOrOverlap || inPeriod(d.date) == true {
+			continue
+		}
+
+		date := d.date.Format("2006-01-02")
+		weekend := d.date.Weekday() == time.Saturday || d.date.Weekday() == time.Sunday
+		if weekend == true || (count[date] > 1 && overlapped[date] == true) {
+			substitute(d.date, d.name, true)
+		}
+		overlapped[date] = true
+	}
+
+	var result []Holiday
+	for _, h := range holidays {
+		result = append(result, h)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date < result[j].Date
+	})
+
+	return result
+}
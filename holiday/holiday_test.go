@@ -0,0 +1,159 @@
+package holiday
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		year        int
+		dates       []string // 공휴일 날짜 목록(날짜순)
+		substitutes []string // 대체공휴일 날짜 목록(날짜순)
+	}{
+		{
+			// 설날이 일요일이므로 설날 연휴 다음날이 대체공휴일, 부처님오신날이 토요일이므로 다음 월요일이 대체공휴일이다.
+			year: 2023,
+			dates: []string{
+				"2023-01-01", "2023-01-21", "2023-01-22", "2023-01-23", "2023-01-24", "2023-03-01", "2023-05-05", "2023-05-27", "2023-05-29",
+				"2023-06-06", "2023-08-15", "2023-09-28", "2023-09-29", "2023-09-30", "2023-10-03", "2023-10-09", "2023-12-25",
+			},
+			substitutes: []string{"2023-01-24", "2023-05-29"},
+		},
+		{
+			// 어린이날과 부처님오신날이 같은 날(월요일)이므로 다음날 하루가 대체공휴일이다.
+			// 삼일절이 토요일, 추석 전날이 일요일이므로 각각 대체공휴일이 지정된다.
+			year: 2025,
+			dates: []string{
+				"2025-01-01", "2025-01-28", "2025-01-29", "2025-01-30", "2025-03-01", "2025-03-03", "2025-05-05", "2025-05-06", "2025-06-06",
+				"2025-08-15", "2025-10-03", "2025-10-05", "2025-10-06", "2025-10-07", "2025-10-08", "2025-10-09", "2025-12-25",
+			},
+			substitutes: []string{"2025-03-03", "2025-05-06", "2025-10-08"},
+		},
+		{
+			// 추석과 개천절이 같은 날이므로 추석 연휴 다음날 하루만 대체공휴일이다.
+			year: 2028,
+			dates: []string{
+				"2028-01-01", "2028-01-26", "2028-01-27", "2028-01-28", "2028-03-01", "2028-05-02", "2028-05-05", "2028-06-06",
+				"2028-08-15", "2028-10-02", "2028-10-03", "2028-10-04", "2028-10-05", "2028-10-09", "2028-12-25",
+			},
+			substitutes: []string{"2028-10-05"},
+		},
+	}
+
+	for _, tt := range tests {
+		holidays := NewCalendar(nil).Year(tt.year)
+
+		var dates, substitutes []string
+		for _, h := range holidays {
+			dates = append(dates, h.Date)
+			if h.Substitute == true {
+				substitutes = append(substitutes, h.Date)
+			}
+		}
+		if reflect.DeepEqual(dates, tt.dates) == false {
+			t.Errorf("%d년 공휴일 = %v, want %v", tt.year, dates, tt.dates)
+		}
+		if reflect.DeepEqual(substitutes, tt.substitutes) == false {
+			t.Errorf("%d년 대체공휴일 = %v, want %v", tt.year, substitutes, tt.substitutes)
+		}
+	}
+}
+
+func TestComputeOverlappedNames(t *testing.T) {
+	c := NewCalendar(nil)
+	tests := []struct {
+		date  time.Time
+		names []string
+	}{
+		{solarDate(2025, 5, 5), []string{"어린이날", "부처님오신날"}},
+		{solarDate(2028, 10, 3), []string{"개천절", "추석"}},
+	}
+
+	for _, tt := range tests {
+		h, ok := c.Lookup(tt.date)
+		if ok == false {
+			t.Errorf("%s은 공휴일이어야 합니다", tt.date.Format("2006-01-02"))
+			continue
+		}
+		for _, name := range tt.names {
+			if strings.Contains(h.Name, name) == false {
+				t.Errorf("%s 공휴일명 = %q, %q가 포함되어야 합니다", h.Date, h.Name, name)
+			}
+		}
+	}
+}
+
+func TestComputeLunarHoliday(t *testing.T) {
+	// 한국천문연구원 음양력 변환 기준의 음력 공휴일(1988년, 1997년은 중국 음력과 설날이 다른 해이다)
+	tests := []struct {
+		year                     int
+		seollal, buddha, chuseok string
+	}{
+		{1988, "1988-02-18", "1988-05-23", "1988-09-25"},
+		{1997, "1997-02-08", "1997-05-14", "1997-09-16"},
+		{2019, "2019-02-05", "2019-05-12", "2019-09-13"},
+		{2020, "2020-01-25", "2020-04-30", "2020-10-01"},
+		{2021, "2021-02-12", "2021-05-19", "2021-09-21"},
+		{2022, "2022-02-01", "2022-05-08", "2022-09-10"},
+		{2023, "2023-01-22", "2023-05-27", "2023-09-29"},
+		{2024, "2024-02-10", "2024-05-15", "2024-09-17"},
+		{2025, "2025-01-29", "2025-05-05", "2025-10-06"},
+		{2026, "2026-02-17", "2026-05-24", "2026-09-25"},
+		{2027, "2027-02-07", "2027-05-13", "2027-09-15"},
+		{2028, "2028-01-27", "2028-05-02", "2028-10-03"},
+		{2029, "2029-02-13", "2029-05-20", "2029-09-22"},
+		{2030, "2030-02-03", "2030-05-09", "2030-09-12"},
+		{2033, "2033-01-31", "2033-05-06", "2033-09-08"},
+		{2034, "2034-02-19", "2034-05-25", "2034-09-27"},
+	}
+
+	for _, tt := range tests {
+		lh, ok := computeLunarHoliday(tt.year)
+		if ok == false {
+			t.Errorf("computeLunarHoliday(%d) ok = false, want true", tt.year)
+			continue
+		}
+
+		got := []string{lh.seollal.Format("2006-01-02"), lh.buddha.Format("2006-01-02"), lh.chuseok.Format("2006-01-02")}
+		want := []string{tt.seollal, tt.buddha, tt.chuseok}
+		if reflect.DeepEqual(got, want) == false {
+			t.Errorf("computeLunarHoliday(%d) = %v, want %v", tt.year, got, want)
+		}
+	}
+}
+
+func TestComputeUncoveredYear(t *testing.T) {
+	first, last := LunarYears()
+	if first != 1961 || last != 2100 {
+		t.Errorf("LunarYears() = %d, %d, want 1961, 2100", first, last)
+	}
+	if LunarCovered(2100) == false || LunarCovered(2101) == true || LunarCovered(1960) == true {
+		t.Errorf("LunarCovered(2100) = %v, LunarCovered(2101) = %v, LunarCovered(1960) = %v", LunarCovered(2100), LunarCovered(2101), LunarCovered(1960))
+	}
+
+	// 음력 공휴일을 계산할 수 없는 연도는 양력 공휴일만 계산된다.
+	for _, h := range Compute(2101) {
+		if strings.Contains(h.Name, "설날") == true || strings.Contains(h.Name, "추석") == true || strings.Contains(h.Name, "부처님오신날") == true {
+			t.Errorf("2101년 공휴일에 음력 공휴일(%s %s)이 포함되어 있습니다", h.Date, h.Name)
+		}
+	}
+}
+
+func TestCalendarExtra(t *testing.T) {
+	c := NewCalendar([]Holiday{{Date: "2025-06-03", Name: "제21대 대통령 선거일"}})
+
+	if h, ok := c.Lookup(solarDate(2025, 6, 3)); ok == false || h.Name != "제21대 대통령 선거일" {
+		t.Errorf("Lookup(2025-06-03) = %v, %v", h, ok)
+	}
+	if c.IsHoliday(solarDate(2025, 6, 4)) == true {
+		t.Errorf("IsHoliday(2025-06-04) = true, want false")
+	}
+
+	var nilCalendar *Calendar
+	if nilCalendar.IsHoliday(solarDate(2025, 1, 1)) == true {
+		t.Errorf("nil 공휴일 달력의 IsHoliday() = true, want false")
+	}
+}
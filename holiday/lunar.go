@@ -0,0 +1,253 @@
+package holiday
+
+import (
+	"math"
+	"time"
+)
+
+// lunarHoliday 음력 공휴일의 양력 날짜
+type lunarHoliday struct {
+	seollal time.Time // 설날(음력 1월 1일)
+	buddha  time.Time // 부처님오신날(음력 4월 8일)
+	chuseok time.Time // 추석(음력 8월 15일)
+}
+
+// 음력 공휴일을 계산할 수 있는 연도 범위
+// 한국 표준시(UTC+9)가 다시 사용된 1961년부터, 근사식의 오차가 하루를 넘지 않는 2100년까지 계산한다.
+const (
+	lunarFirstYear = 1961
+	lunarLastYear  = 2100
+)
+
+// kst 음력 날짜를 정하는 기준 시간대(한국 표준시, 동경 135도)
+var kst = time.FixedZone("KST", 9*60*60)
+
+// LunarCovered 지정된 연도의 음력 공휴일을 계산할 수 있는지의 여부를 반환한다.
+func LunarCovered(year int) bool {
+	return year >= lunarFirstYear && year <= lunarLastYear
+}
+
+// LunarYears 음력 공휴일을 계산할 수 있는 처음 연도와 마지막 연도를 반환한다.
+func LunarYears() (first, last int) {
+	return lunarFirstYear, lunarLastYear
+}
+
+// computeLunarHoliday 지정된 연도의 음력 공휴일을 양력 날짜로 계산한다.
+// 음력은 한국천문연구원과 같은 방식(정기법)으로 계산한다. 합삭일이 음력 초하루이고, 동지가 있는 달이 11월이며,
+// 11월부터 다음 11월까지 13개월이면 중기(태양황경이 30도의 배수인 절기)가 없는 첫 번째 달이 윤달이다.
+// 합삭 및 절기 시각은 천문 근사식(J. Meeus, Astronomical Algorithms)으로 계산하며 오차는 수 분 이내이다.
+func computeLunarHoliday(year int) (lunarHoliday, bool) {
+	if LunarCovered(year) == false {
+		return lunarHoliday{}, false
+	}
+
+	months := lunarMonths(year)
+
+	// 전년도 동지가 있는 11월부터 세어 윤달을 제외한 세 번째 달이 1월이다.
+	start := func(month int) time.Time {
+		number := 11
+		for _, m := range months {
+			if m.leap == true {
+				continue
+			}
+			if number == month {
+				return m.start
+			}
+			if number++; number > 12 {
+				number = 1
+			}
+		}
+		panic("음력 월을 찾을 수 없습니다")
+	}
+
+	return lunarHoliday{
+		seollal: start(1),
+		buddha:  start(4).AddDate(0, 0, 7),
+		chuseok: start(8).AddDate(0, 0, 14),
+	}, true
+}
+
+// lunarMonth 음력 월
+type lunarMonth struct {
+	start time.Time // 초하루의 양력 날짜
+	leap  bool      // 윤달인지의 여부
+}
+
+// lunarMonths 전년도 동지가 있는 달(11월)부터 지정된 연도의 동지가 있는 달의 전달까지의 음력 월 목록을 반환한다.
+func lunarMonths(year int) []lunarMonth {
+	solstice := func(y int) time.Time {
+		return kstDate(solarTermJDE(270, float64(y)+0.97))
+	}
+	from, to := solstice(year-1), solstice(year)
+
+	// 동지 이전의 가장 가까운 합삭부터 다음 동지 이전의 가장 가까운 합삭까지의 합삭일 목록
+	k := math.Floor((float64(year-1)+0.97-2000)*12.3685) - 1
+	for kstDate(newMoonJDE(k+1)).After(from) == false {
+		k++
+	}
+	for kstDate(newMoonJDE(k)).After(from) == true {
+		k--
+	}
+	var starts []time.Time
+	for ; kstDate(newMoonJDE(k)).After(to) == false; k++ {
+		starts = append(starts, kstDate(newMoonJDE(k)))
+	}
+	// 마지막 합삭은 다음 11월의 초하루이므로 달의 끝을 정하는 데만 사용한다.
+	next := starts[len(starts)-1]
+	starts = starts[:len(starts)-1]
+
+	months := make([]lunarMonth, len(starts))
+	leapFound := len(starts) == 12
+	for i, s := range starts {
+		months[i].start = s
+
+		end := next
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		if leapFound == false && i > 0 && hasPrincipalTerm(s, end) == false {
+			months[i].leap = true
+			leapFound = true
+		}
+	}
+
+	return months
+}
+
+// hasPrincipalTerm 양력 날짜 [start, end) 사이에 중기(태양황경이 30도의 배수인 절기)가 있는지의 여부를 반환한다.
+func hasPrincipalTerm(start, end time.Time) bool {
+	jd := julianDay(start)
+
+	// start 이후의 첫 번째 중기
+	longitude := math.Ceil(sunLongitude(jd)/30) * 30
+	term := kstDate(solarTermJDE(longitude, 2000+(jd-2451545)/365.2422))
+
+	return term.Before(end)
+}
+
+// newMoonJDE k번째 합삭(2000년 1월 6일의 합삭이 0번째)의 율리우스일(역학시)을 계산한다.
+func newMoonJDE(k float64) float64 {
+	t := k / 1236.85
+	jde := 2451550.09766 + 29.530588861*k + 0.00015437*t*t - 0.000000150*t*t*t + 0.00000000073*t*t*t*t
+
+	e := 1 - 0.002516*t - 0.0000074*t*t
+	m := radians(2.5534 + 29.10535670*k - 0.0000014*t*t - 0.00000011*t*t*t)
+	mp := radians(201.5643 + 385.81693528*k + 0.0107582*t*t + 0.00001238*t*t*t - 0.000000058*t*t*t*t)
+	f := radians(160.7108 + 390.67050284*k - 0.0016118*t*t - 0.00000227*t*t*t + 0.000000011*t*t*t*t)
+	omega := radians(124.7746 - 1.56375588*k + 0.0020672*t*t + 0.00000215*t*t*t)
+
+	jde += -0.40720*math.Sin(mp) +
+		0.17241*e*math.Sin(m) +
+		0.01608*math.Sin(2*mp) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(mp-m) -
+		0.00514*e*math.Sin(mp+m) +
+		0.00208*e*e*math.Sin(2*m) -
+		0.00111*math.Sin(mp-2*f) -
+		0.00057*math.Sin(mp+2*f) +
+		0.00056*e*math.Sin(2*mp+m) -
+		0.00042*math.Sin(3*mp) +
+		0.00042*e*math.Sin(m+2*f) +
+		0.00038*e*math.Sin(m-2*f) -
+		0.00024*e*math.Sin(2*mp-m) -
+		0.00017*math.Sin(omega) -
+		0.00007*math.Sin(mp+2*m) +
+		0.00004*math.Sin(2*mp-2*f) +
+		0.00004*math.Sin(3*m) +
+		0.00003*math.Sin(mp+m-2*f) +
+		0.00003*math.Sin(2*mp+2*f) -
+		0.00003*math.Sin(mp+m+2*f) +
+		0.00003*math.Sin(mp-m+2*f) -
+		0.00002*math.Sin(mp-m-2*f) -
+		0.00002*math.Sin(3*mp+m) +
+		0.00002*math.Sin(4*mp)
+
+	// 행성에 의한 보정
+	planetary := [][3]float64{
+		{299.77, 0.107408, 0.000325}, {251.88, 0.016321, 0.000165}, {251.83, 26.651886, 0.000164},
+		{349.42, 36.412478, 0.000126}, {84.66, 18.206239, 0.000110}, {141.74, 53.303771, 0.000062},
+		{207.14, 2.453732, 0.000060}, {154.84, 7.306860, 0.000056}, {34.52, 27.261239, 0.000047},
+		{207.19, 0.121824, 0.000042}, {291.34, 1.844379, 0.000040}, {161.72, 24.198154, 0.000037},
+		{239.56, 25.513099, 0.000035}, {331.55, 3.592518, 0.000023},
+	}
+	for i, p := range planetary {
+		a := p[0] + p[1]*k
+		if i == 0 {
+			a -= 0.009173 * t * t
+		}
+		jde += p[2] * math.Sin(radians(a))
+	}
+
+	return jde
+}
+
+// solarTermJDE 태양황경이 longitude(도)가 되는 시각의 율리우스일(역학시)을 계산한다. 시각은 approxYear(소수 연도) 부근에서 찾는다.
+func solarTermJDE(longitude float64, approxYear float64) float64 {
+	jde := 2451545 + (approxYear-2000)*365.2422
+	for i := 0; i < 50; i++ {
+		diff := math.Mod(longitude-sunLongitude(jde)+540, 360) - 180
+		jde += diff * 365.2422 / 360
+		if math.Abs(diff) < 0.000001 {
+			break
+		}
+	}
+	return jde
+}
+
+// sunLongitude 율리우스일(역학시)의 태양의 겉보기 황경(도, 0~360)을 계산한다.
+func sunLongitude(jde float64) float64 {
+	t := (jde - 2451545) / 36525
+
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := radians(357.52911 + 35999.05029*t - 0.0001537*t*t)
+	c := (1.914602-0.004817*t-0.000014*t*t)*math.Sin(m) + (0.019993-0.000101*t)*math.Sin(2*m) + 0.000289*math.Sin(3*m)
+	omega := radians(125.04 - 1934.136*t)
+
+	longitude := math.Mod(l0+c-0.00569-0.00478*math.Sin(omega), 360)
+	if longitude < 0 {
+		longitude += 360
+	}
+	return longitude
+}
+
+// kstDate 율리우스일(역학시)을 한국 표준시의 양력 날짜로 변환한다.
+func kstDate(jde float64) time.Time {
+	year := 2000 + (jde-2451545)/365.25
+	unix := (jde-2440587.5)*86400 - deltaT(year)
+
+	t := time.Unix(int64(math.Floor(unix)), 0).In(kst)
+
+	return solarDate(t.Year(), t.Month(), t.Day())
+}
+
+// julianDay 양력 날짜(한국 표준시 0시)의 율리우스일을 계산한다.
+func julianDay(date time.Time) float64 {
+	t := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, kst)
+	return float64(t.Unix())/86400 + 2440587.5
+}
+
+// deltaT 역학시와 세계시의 차이(초)를 계산한다(F. Espenak, J. Meeus의 다항식 근사).
+func deltaT(year float64) float64 {
+	switch {
+	case year < 1986:
+		t := year - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case year < 2005:
+		t := year - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case year < 2050:
+		t := year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	default:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-year)
+	}
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func solarDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/holiday"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"strconv"
+	"time"
+)
+
+// holidaysCommand 지정된 연도(기본값:검색년도)의 공휴일 목록을 출력한다.
+// 설정 파일의 'holidays' 항목에 지정된 임시공휴일도 함께 출력된다.
+func holidaysCommand(cfg *config.Config, args []string) {
+	year, err := strconv.Atoi(searchYear)
+	utils.CheckErr(err)
+
+	if len(args) > 0 {
+		if year, err = strconv.Atoi(args[0]); err != nil {
+			log.Fatalf("연도가 올바르지 않습니다(연도:%s)", args[0])
+		}
+	}
+
+	// 음력 공휴일을 계산할 수 없는 연도는 설날, 부처님오신날, 추석이 빠진 목록이므로 눈에 띄게 경고한다.
+	if holiday.LunarCovered(year) == false {
+		first, last := holiday.LunarYears()
+		fmt.Printf("※ 경고: %d년은 음력 공휴일을 계산할 수 있는 연도(%d~%d년)가 아니므로 설날, 부처님오신날, 추석 및 그 대체공휴일이 아래 목록에서 빠져 있습니다.\n", year, first, last)
+		fmt.Printf("※ 정확한 공휴일이 필요하면 설정 파일의 'holidays' 항목에 직접 추가하세요.\n\n")
+	}
+
+	weekdays := []string{"일", "월", "화", "수", "목", "금", "토"}
+	for _, h := range cfg.HolidayCalendar().Year(year) {
+		t, err := time.ParseInLocation("2006-01-02", h.Date, time.Local)
+		utils.CheckErr(err)
+
+		fmt.Printf("%s(%s) %s\n", h.Date, weekdays[t.Weekday()], h.Name)
+	}
+}
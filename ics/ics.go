@@ -0,0 +1,85 @@
+package ics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event iCalendar 일정
+type Event struct {
+	UID         string        // 일정 ID(같은 일정을 다시 내보내면 캘린더에서 갱신된다)
+	Start       time.Time     // 시작일시
+	End         time.Time     // 종료일시
+	Summary     string        // 제목
+	Description string        // 설명
+	Location    string        // 장소
+	URL         string        // 관련 주소
+	Alarm       time.Duration // 시작일시 몇 분 전에 알릴지(0이면 알리지 않는다)
+}
+
+// Write 일정 목록을 iCalendar(.ics) 형식으로 출력한다.
+func Write(w io.Writer, calendarName string, events []Event) error {
+	var sb strings.Builder
+
+	writeLine(&sb, "BEGIN:VCALENDAR")
+	writeLine(&sb, "VERSION:2.0")
+	writeLine(&sb, "PRODID:-//darkkaiser//culturelecture-scrape//KO")
+	writeLine(&sb, "CALSCALE:GREGORIAN")
+	writeLine(&sb, "X-WR-CALNAME:"+escape(calendarName))
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, e := range events {
+		writeLine(&sb, "BEGIN:VEVENT")
+		writeLine(&sb, "UID:"+escape(e.UID))
+		writeLine(&sb, "DTSTAMP:"+stamp)
+		writeLine(&sb, "DTSTART:"+e.Start.UTC().Format("20060102T150405Z"))
+		writeLine(&sb, "DTEND:"+e.End.UTC().Format("20060102T150405Z"))
+		writeLine(&sb, "SUMMARY:"+escape(e.Summary))
+		if e.Description != "" {
+			writeLine(&sb, "DESCRIPTION:"+escape(e.Description))
+		}
+		if e.Location != "" {
+			writeLine(&sb, "LOCATION:"+escape(e.Location))
+		}
+		if e.URL != "" {
+			writeLine(&sb, "URL:"+e.URL)
+		}
+		if e.Alarm > 0 {
+			writeLine(&sb, "BEGIN:VALARM")
+			writeLine(&sb, "ACTION:DISPLAY")
+			writeLine(&sb, "DESCRIPTION:"+escape(e.Summary))
+			writeLine(&sb, fmt.Sprintf("TRIGGER:-PT%dM", int(e.Alarm.Minutes())))
+			writeLine(&sb, "END:VALARM")
+		}
+		writeLine(&sb, "END:VEVENT")
+	}
+
+	writeLine(&sb, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// escape iCalendar 텍스트 값의 특수문자를 이스케이프한다.
+func escape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\r\n", "\\n", "\n", "\\n").Replace(s)
+}
+
+// writeLine 한 줄이 75바이트를 넘지 않도록 접어서 출력한다(RFC 5545 3.1).
+// 멀티바이트 문자가 나뉘지 않도록 문자 단위로 접는다.
+func writeLine(sb *strings.Builder, line string) {
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > 75 {
+			sb.WriteString("\r\n ")
+			n = 1
+		}
+		sb.WriteRune(r)
+		n += size
+	}
+	sb.WriteString("\r\n")
+}
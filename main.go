@@ -1,8 +1,17 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/age"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/expr"
 	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"os"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -23,41 +32,65 @@ var searchYear = "2025"
 // 검색시즌(봄, 여름, 가을, 겨울)
 var searchSeason = "여름"
 
-// 공휴일
-var holidays = []string{
-	"2024-01-01",
-	// "2021-02-11", "2021-02-12", "2021-02-13",
-	// "2021-03-01",
-	// "2021-05-05",
-	// "2021-05-19",
-	// "2021-06-06",
-	// "2021-08-15",
-	// "2021-09-20", "2021-09-21", "2021-09-22",
-	// "2021-10-03",
-	// "2021-10-09",
-	// "2021-12-25",
-}
-
 /********************************************************************************/
 /* 강좌 수집 작업시에 변경되는 값 END                                               */
 /****************************************************************************** */
 
 func main() {
-	now := time.Now()
+	command := "scrape"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
 
-	// 강좌 수강자의 나이 및 개월수를 계산한다.
-	cultureLecturerAge := now.Year() - cultureLecturer.YearOfBirth + 1
+	cfg := config.Load(config.DefaultFileName)
 
-	cultureLecturerMonths := 0
-	cultureLecturerBirthday := time.Date(cultureLecturer.YearOfBirth, time.Month(cultureLecturer.MonthOfBirth), cultureLecturer.DayOfBirth, 0, 0, 0, 0, time.Local)
-	for {
-		cultureLecturerBirthday = cultureLecturerBirthday.AddDate(0, 1, 0)
-		if cultureLecturerBirthday.Unix() > now.Unix() {
-			break
-		}
+	var args []string
+	if len(os.Args) > 2 {
+		args = os.Args[2:]
+	}
 
-		cultureLecturerMonths += 1
+	switch command {
+	case "scrape":
+		scrapeCommand(cfg, args)
+	case "groups":
+		groupsCommand(cfg)
+	case "doctor":
+		doctorCommand(cfg)
+	case "history":
+		historyCommand(cfg, args)
+	case "diff":
+		diffCommand(cfg, args)
+	case "watch":
+		watchCommand(cfg)
+	case "reminders":
+		remindersCommand(cfg, args)
+	case "serve":
+		serveCommand(cfg, args)
+	case "holidays":
+		holidaysCommand(cfg, args)
+	case "explain":
+		explainCommand(cfg, args)
+	case "prices":
+		pricesCommand(cfg, args)
+	case "analyze":
+		analyzeCommand(cfg, args)
+	default:
+		log.Fatalf("지원하지 않는 명령입니다(명령:%s, 지원명령:scrape, groups, doctor, history, diff, watch, reminders, serve, holidays, explain, prices, analyze)", command)
 	}
+}
+
+// scrapeCommand 문화센터 강좌를 수집하여 필터링한 후 파일로 저장한다.
+// -where 옵션으로 조건식(예:day in [토,일] && price <= 50000)을 지정하면 조건식을 만족하는 강좌만 저장한다.
+func scrapeCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("scrape", flag.ExitOnError)
+	where := fs.String("where", "", "강좌가 만족해야 하는 조건식(예:day in [토,일] && start >= 10:00 && price <= 50000 && title ~ \"미술|요리\")")
+	utils.CheckErr(fs.Parse(args))
+
+	whereExpr := parseWhere(*where)
+
+	now := time.Now()
+
+	learners := learnerProfiles(cfg)
 
 	fmt.Println("########################################################")
 	fmt.Println("###                                                  ###")
@@ -68,11 +101,101 @@ func main() {
 	fmt.Println("########################################################")
 	fmt.Println("")
 	fmt.Println(fmt.Sprintf(" ▶ %s년 %s 문화센터 강좌를 수집합니다.", searchYear, searchSeason))
-	fmt.Println(fmt.Sprintf(" ▶ 문화센터 강좌 수강자는 %d세(%d개월) 아이입니다.\n", cultureLecturerAge, cultureLecturerMonths))
+	for _, lc := range learners {
+		fmt.Println(fmt.Sprintf(" ▶ 문화센터 강좌 수강자 %s은(는) 오늘 기준 만 %d세(%d개월) 아이입니다.", lc.Name, age.International.Of(lc.BirthDate(), now), age.Months(lc.BirthDate(), now)))
+		fmt.Println(fmt.Sprintf("   (강좌 연령제한은 체인별 나이 계산 방식에 따라 강좌의 개강일 기준으로 계산됩니다. 오늘 기준 %s)", chainAgesText(cfg, lc.BirthDate(), now)))
+	}
+	fmt.Println("")
+
+	s := scrape.New(cfg)
+	utils.CheckErr(s.Scrape(searchYear, searchSeason))
+
+	if cfg.Snapshot.Enabled == true {
+		utils.CheckErr(saveSnapshot(cfg, now, nil, s.Lectures()))
+	}
+
+	s.FilterLearners(learners, cfg.HolidayCalendar())
+	if whereExpr != nil {
+		s.Where(whereExpr)
+	}
+	if cfg.Export.SortByDistance == true {
+		s.SortByDistance()
+	}
 
-	s := scrape.New()
-	s.Scrape(searchYear, searchSeason)
-	s.Filter(cultureLecturerMonths, cultureLecturerAge, holidays)
+	fileName := fmt.Sprintf("culturelecture-scrape-%d%02d%02d%02d%02d%02d", now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second())
+	if cfg.Export.LearnerFiles == true {
+		for _, lc := range learners {
+			ls := s.Select(scrape.HasLearners(lc.Name))
+			ls.ExportCSV(fmt.Sprintf("%s-%s.csv", fileName, lc.Name))
+			ls.ExportJSON(fmt.Sprintf("%s-%s.json", fileName, lc.Name))
+			ls.ExportICS(fmt.Sprintf("%s-%s.ics", fileName, lc.Name))
+		}
+	} else {
+		s.ExportCSV(fileName + ".csv")
+		s.ExportJSON(fileName + ".json")
+		s.ExportICS(fileName + ".ics")
+	}
+	s.ExportExcludedCSV(fileName + "-excluded.csv")
+	s.ExportProgramsCSV(fileName + "-programs.csv")
+	s.ExportProgramsJSON(fileName + "-programs.json")
+	s.ExportPriceReport(fileName + "-prices.csv")
+
+	// 형제, 자매가 함께 수강할 수 있는 강좌를 따로 저장한다.
+	if len(learners) > 1 {
+		var names []string
+		for _, lc := range learners {
+			names = append(names, lc.Name)
+		}
+		together := s.Select(scrape.HasLearners(names...))
+		log.Printf("수강자 모두가 함께 수강할 수 있는 강좌는 총 %d건입니다.", len(together.Lectures()))
+		together.ExportCSV(fileName + "-together.csv")
+		together.ExportJSON(fileName + "-together.json")
+	}
+}
+
+// parseWhere -where 옵션으로 지정된 조건식을 해석한다. 조건식이 비어 있으면 nil을 반환한다.
+// 조건식이 올바르지 않으면 오류가 발생한 위치를 표시하고 실행을 중단한다.
+// chainAgesText 설정된 체인들이 사용하는 나이 계산 방식별로 기준일(at) 현재 나이를 '세는 나이 10세(emart, homeplus)' 형식으로 반환한다.
+func chainAgesText(cfg *config.Config, birthDate time.Time, at time.Time) string {
+	chainsByConvention := make(map[age.Convention][]string)
+	for chain, cc := range cfg.Chains {
+		chainsByConvention[cc.Convention()] = append(chainsByConvention[cc.Convention()], chain)
+	}
+
+	var texts []string
+	for _, c := range age.Conventions {
+		chains := chainsByConvention[c]
+		if len(chains) == 0 {
+			continue
+		}
+		sort.Strings(chains)
+
+		texts = append(texts, fmt.Sprintf("%s %d세(%s)", c, c.Of(birthDate, at), strings.Join(chains, ", ")))
+	}
+	return strings.Join(texts, ", ")
+}
+
+func parseWhere(where string) *expr.Expr {
+	if where == "" {
+		return nil
+	}
+
+	e, err := expr.Parse(where)
+	if err != nil {
+		log.Fatalf("%s\n%s", err, err.(*expr.SyntaxError).Pointer())
+	}
+	return e
+}
+
+// learnerProfiles 강좌 수강자 목록을 반환한다.
+// 설정 파일에 수강자가 지정되지 않으면 cultureLecturer를 수강자로 사용한다.
+func learnerProfiles(cfg *config.Config) []config.LearnerConfig {
+	if len(cfg.Learners) > 0 {
+		return cfg.Learners
+	}
 
-	s.ExportCSV(fmt.Sprintf("culturelecture-scrape-%d%02d%02d%02d%02d%02d.csv", now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second()))
+	return []config.LearnerConfig{{
+		Name:     "수강자",
+		Birthday: fmt.Sprintf("%04d-%02d-%02d", cultureLecturer.YearOfBirth, cultureLecturer.MonthOfBirth, cultureLecturer.DayOfBirth),
+	}}
 }
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Command 알림 이벤트마다 로컬 명령을 실행하는 알림 채널
+// 알림 이벤트는 JSON으로 명령의 표준입력에 전달된다.
+type Command struct {
+	path    string
+	args    []string
+	timeout time.Duration
+}
+
+func NewCommand(path string, args []string, timeout time.Duration) *Command {
+	return &Command{
+		path:    path,
+		args:    args,
+		timeout: timeout,
+	}
+}
+
+func (c *Command) Name() string {
+	return "command"
+}
+
+func (c *Command) Notify(events []Event) error {
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		if err = c.run(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Command) run(stdin []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.path, c.args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("명령(%s) 실행이 실패하였습니다(%s, %s)", c.path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
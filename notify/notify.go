@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"log"
+	"text/template"
+	"time"
+)
+
+// EventType 알림 이벤트 유형
+type EventType string
+
+// 지원가능한 알림 이벤트 유형 값
+const (
+	EventAdded         EventType = "added"         // 새로운 강좌가 수집됨
+	EventStatusChanged EventType = "statusChanged" // 강좌의 접수상태가 변경됨
+	EventRegisterSoon  EventType = "registerSoon"  // 강좌의 접수시작이 임박함
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventAdded:
+		return "신규 강좌"
+	case EventStatusChanged:
+		return "접수상태 변경"
+	case EventRegisterSoon:
+		return "접수시작 예정"
+	default:
+		return string(t)
+	}
+}
+
+// Event 알림 이벤트
+type Event struct {
+	Type      EventType                `json:"type"`      // 이벤트 유형
+	Time      time.Time                `json:"time"`      // 이벤트 발생 시각
+	Lecture   lectures.Lecture         `json:"lecture"`   // 강좌
+	OldStatus lectures.ReceptionStatus `json:"oldStatus"` // 변경전 접수상태(새로 수집된 강좌는 알수없음)
+	NewStatus lectures.ReceptionStatus `json:"newStatus"` // 변경후 접수상태
+}
+
+// DefaultTemplate 기본 알림 메시지 템플릿
+const DefaultTemplate = `[{{.Type}}] {{.Lecture.StoreName}} - {{.Lecture.Title}}
+강의시간 : {{.Lecture.DayOfTheWeek}} {{.Lecture.StartTime}}~{{.Lecture.EndTime}} (개강일 {{.Lecture.StartDate}})
+수강료 : {{.Lecture.Price}}
+접수상태 : {{if eq .Type "statusChanged"}}{{.OldStatus}} → {{end}}{{.NewStatus}}{{if .Lecture.RegisterStart}}
+접수기간 : {{.Lecture.RegisterStart}} ~ {{.Lecture.RegisterEnd}}{{end}}
+상세페이지 : {{.Lecture.DetailPageUrl}}`
+
+// Notifier 알림 이벤트를 전달하는 알림 채널
+type Notifier interface {
+	Name() string
+	Notify(events []Event) error
+}
+
+// Formatter 알림 이벤트를 템플릿으로 알림 메시지로 변환한다.
+type Formatter struct {
+	tmpl *template.Template
+}
+
+func NewFormatter(text string) (*Formatter, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+
+	tmpl, err := template.New("notify").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("알림 메시지 템플릿이 올바르지 않습니다(%s)", err)
+	}
+
+	return &Formatter{tmpl: tmpl}, nil
+}
+
+// Format 알림 이벤트를 알림 메시지로 변환한다.
+func (f *Formatter) Format(event Event) (string, error) {
+	var buf bytes.Buffer
+	if err := f.tmpl.Execute(&buf, event); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Subject 알림 이벤트 목록의 제목을 반환한다.
+func Subject(events []Event) string {
+	if len(events) == 1 {
+		return fmt.Sprintf("[%s] %s - %s", events[0].Type, events[0].Lecture.StoreName, events[0].Lecture.Title)
+	}
+	return fmt.Sprintf("문화센터 강좌 알림 %d건", len(events))
+}
+
+// Multi 여러 알림 채널로 알림 이벤트를 전달한다.
+// 일부 알림 채널이 실패하더라도 나머지 알림 채널로 계속 전달한다.
+type Multi []Notifier
+
+func (m Multi) Name() string {
+	return "multi"
+}
+
+func (m Multi) Notify(events []Event) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(events); err != nil {
+			log.Printf("알림 채널(%s)로 알림을 전달하는 중에 오류가 발생하였습니다(%s)", n.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// New 알림 설정에서 사용하도록 지정된 알림 채널을 생성한다.
+func New(nc config.NotifyConfig) (Notifier, error) {
+	f, err := NewFormatter(nc.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Multi
+	if nc.Stdout == true {
+		m = append(m, NewStdout(f))
+	}
+	if nc.Webhook.Enabled == true {
+		m = append(m, NewWebhook(nc.Webhook.URL, f))
+	}
+	if nc.SMTP.Enabled == true {
+		m = append(m, NewSMTP(nc.SMTP, f))
+	}
+	if nc.Command.Enabled == true {
+		m = append(m, NewCommand(nc.Command.Path, nc.Command.Args, nc.Command.TimeoutDuration()))
+	}
+
+	return m, nil
+}
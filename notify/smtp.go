@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// SMTP 알림 메시지를 이메일로 보내는 알림 채널
+// 알림 이벤트 목록은 한 통의 이메일로 보낸다.
+type SMTP struct {
+	config config.SMTPNotifyConfig
+	f      *Formatter
+}
+
+func NewSMTP(sc config.SMTPNotifyConfig, f *Formatter) *SMTP {
+	return &SMTP{
+		config: sc,
+		f:      f,
+	}
+}
+
+func (s *SMTP) Name() string {
+	return "smtp"
+}
+
+func (s *SMTP) Notify(events []Event) error {
+	var messages []string
+	for _, event := range events {
+		message, err := s.f.Format(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("From: %s\r\n", s.config.From))
+	sb.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(s.config.To, ", ")))
+	sb.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", Subject(events))))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(strings.Join(messages, "\n\n"), "\n", "\r\n"))
+	sb.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	return smtp.SendMail(net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port)), auth, s.config.From, s.config.To, []byte(sb.String()))
+}
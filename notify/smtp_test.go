@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"bufio"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// smtpMessage 테스트용 SMTP 서버가 받은 메일
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// serveSMTP 인증 및 STARTTLS를 지원하지 않는 최소한의 SMTP 서버로 한 연결을 처리하고, 받은 메일을 전달한다.
+func serveSMTP(t *testing.T, l net.Listener, messages chan<- smtpMessage) {
+	conn, err := l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(s string) {
+		_, _ = conn.Write([]byte(s + "\r\n"))
+	}
+
+	var m smtpMessage
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			m.from = line
+			reply("250 OK")
+		case "RCPT":
+			m.to = append(m.to, line)
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var sb strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				sb.WriteString(dataLine)
+			}
+			m.data = sb.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			messages <- m
+			return
+		default:
+			t.Errorf("지원하지 않는 SMTP 명령입니다(%s)", line)
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPNotify(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	messages := make(chan smtpMessage, 1)
+	go serveSMTP(t, l, messages)
+
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	portNo, _ := strconv.Atoi(port)
+
+	f, err := NewFormatter("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc := config.SMTPNotifyConfig{Enabled: true, Host: host, Port: portNo, From: "scrape@example.com", To: []string{"parent@example.com", "family@example.com"}}
+	if err = NewSMTP(sc, f).Notify(testEvents()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	// 알림 이벤트 목록은 한 통의 이메일로 보낸다.
+	m := <-messages
+	if strings.Contains(m.from, "<scrape@example.com>") == false {
+		t.Errorf("MAIL = %q", m.from)
+	}
+	if len(m.to) != 2 || strings.Contains(m.to[1], "<family@example.com>") == false {
+		t.Errorf("RCPT = %q", m.to)
+	}
+	for _, want := range []string{"To: parent@example.com, family@example.com\r\n", "Subject: =?UTF-8?b?", "[신규 강좌] 이마트 여수점 - 유아 발레\r\n", "[접수상태 변경] 롯데마트 여수점 - 아기 체육\r\n"} {
+		if strings.Contains(m.data, want) == false {
+			t.Errorf("메일 본문에 %q가 없습니다:\n%s", want, m.data)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Stdout 알림 메시지를 표준출력으로 출력하는 알림 채널
+type Stdout struct {
+	w io.Writer
+	f *Formatter
+}
+
+func NewStdout(f *Formatter) *Stdout {
+	return &Stdout{
+		w: os.Stdout,
+		f: f,
+	}
+}
+
+func (s *Stdout) Name() string {
+	return "stdout"
+}
+
+func (s *Stdout) Notify(events []Event) error {
+	for _, event := range events {
+		message, err := s.f.Format(event)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(s.w, "%s\n\n", message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
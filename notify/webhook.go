@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook 알림 이벤트를 JSON으로 POST하는 알림 채널
+type Webhook struct {
+	url    string
+	f      *Formatter
+	client *http.Client
+}
+
+// webhookPayload 웹훅으로 전송되는 알림 이벤트
+type webhookPayload struct {
+	Event
+	Message string `json:"message"` // 알림 메시지
+}
+
+func NewWebhook(url string, f *Formatter) *Webhook {
+	return &Webhook{
+		url:    url,
+		f:      f,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+// Notify 알림 이벤트를 하나씩 웹훅 주소로 POST한다.
+func (w *Webhook) Notify(events []Event) error {
+	for _, event := range events {
+		message, err := w.f.Format(event)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(webhookPayload{Event: event, Message: message})
+		if err != nil {
+			return err
+		}
+
+		res, err := w.client.Post(w.url, "application/json; charset=utf-8", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return fmt.Errorf("request failed with Status: %d", res.StatusCode)
+		}
+	}
+	return nil
+}
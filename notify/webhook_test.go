@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"encoding/json"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testEvents 테스트에 사용할 알림 이벤트 목록을 반환한다.
+func testEvents() []Event {
+	now := time.Date(2025, 2, 20, 10, 0, 0, 0, time.Local)
+	return []Event{
+		{
+			Type:      EventAdded,
+			Time:      now,
+			Lecture:   lectures.Lecture{ID: "emart/560/12345", StoreName: "이마트 여수점", Title: "유아 발레", Status: lectures.ReceptionStatusPossible},
+			OldStatus: lectures.ReceptionStatusUnknown,
+			NewStatus: lectures.ReceptionStatusPossible,
+		},
+		{
+			Type:      EventStatusChanged,
+			Time:      now,
+			Lecture:   lectures.Lecture{ID: "lottemart/705/67890", StoreName: "롯데마트 여수점", Title: "아기 체육", Status: lectures.ReceptionStatusClosed},
+			OldStatus: lectures.ReceptionStatusPossible,
+			NewStatus: lectures.ReceptionStatusClosed,
+		},
+	}
+}
+
+func TestWebhookNotify(t *testing.T) {
+	var payloads []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") == false {
+			t.Errorf("method = %s, content-type = %s", r.Method, r.Header.Get("Content-Type"))
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var payload map[string]any
+		if err = json.Unmarshal(data, &payload); err != nil {
+			t.Fatalf("웹훅 본문이 JSON 형식이 아닙니다(%s): %s", err, data)
+		}
+		payloads = append(payloads, payload)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	f, err := NewFormatter("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = NewWebhook(srv.URL, f).Notify(testEvents()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	// 알림 이벤트는 하나씩 POST된다.
+	if len(payloads) != 2 {
+		t.Fatalf("요청 갯수 = %d, want 2", len(payloads))
+	}
+	if payloads[0]["type"] != "added" || payloads[1]["type"] != "statusChanged" {
+		t.Errorf("type = %v, %v", payloads[0]["type"], payloads[1]["type"])
+	}
+	if payloads[1]["oldStatus"] != "접수가능" || payloads[1]["newStatus"] != "접수마감" {
+		t.Errorf("oldStatus = %v, newStatus = %v", payloads[1]["oldStatus"], payloads[1]["newStatus"])
+	}
+	if lecture, _ := payloads[0]["lecture"].(map[string]any); lecture["id"] != "emart/560/12345" {
+		t.Errorf("lecture = %v", payloads[0]["lecture"])
+	}
+	if message, _ := payloads[1]["message"].(string); strings.Contains(message, "접수상태 : 접수가능 → 접수마감") == false {
+		t.Errorf("message = %q", message)
+	}
+}
+
+func TestWebhookNotifyFailure(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	f, err := NewFormatter("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewWebhook(srv.URL, f).Notify(testEvents())
+	if err == nil || strings.Contains(err.Error(), "500") == false {
+		t.Errorf("Notify() error = %v, want status 500 error", err)
+	}
+	if requests != 1 {
+		t.Errorf("요청 갯수 = %d, 실패한 후에는 나머지 알림 이벤트를 전송하지 않아야 합니다", requests)
+	}
+}
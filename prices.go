@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"strings"
+)
+
+// pricesCommand 강좌의 1인 1회당 수강료를 체인별, 점포별, 활동 분류별로 요약하여 출력한다.
+func pricesCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("prices", flag.ExitOnError)
+	runID := fs.Uint64("run", 0, "강좌 목록을 읽어들일 스냅샷 저장소의 실행 ID(0이면 검색년도 및 검색시즌이 같은 가장 최근의 실행)")
+	fileName := fs.String("file", "", "스냅샷 저장소 대신 강좌 목록을 읽어들일 CSV 또는 JSON 파일")
+	where := fs.String("where", "", "요약할 강좌가 만족해야 하는 조건식(예:category == dance)")
+	by := fs.String("by", "", "요약 구분(chain, store, category, 지정하지 않으면 전체)")
+	utils.CheckErr(fs.Parse(args))
+
+	kinds := scrape.PriceSummaryKinds
+	if *by != "" {
+		if utils.Contains(scrape.PriceSummaryKinds, *by) == false {
+			log.Fatalf("지원하지 않는 요약 구분입니다(구분:%s, 지원구분:%s)", *by, strings.Join(scrape.PriceSummaryKinds, ", "))
+		}
+		kinds = []string{*by}
+	}
+
+	whereExpr := parseWhere(*where)
+
+	var lectureList []lectures.Lecture
+	if *fileName != "" {
+		var err error
+		lectureList, err = scrape.ReadFile(*fileName)
+		utils.CheckErr(err)
+	} else {
+		if cfg.Snapshot.Enabled == false {
+			log.Fatalf("스냅샷 저장소를 사용하지 않도록 설정되어 있습니다(-file 옵션으로 강좌 목록 파일을 지정하세요)")
+		}
+		lectureList = snapshotLectures(cfg, *runID, "-file 옵션으로 강좌 목록 파일을 지정하세요")
+	}
+
+	s := scrape.New(cfg)
+	s.SetLectures(lectureList)
+	s.Classify()
+	s.ExpandSessionDates(cfg.HolidayCalendar())
+	s.NormalizePrices()
+	if whereExpr != nil {
+		s = s.Select(whereExpr.Match)
+	}
+
+	for i, kind := range kinds {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("[%s별 1인 1회당 수강료]\n", scrape.PriceSummaryKindName(kind))
+		for _, ps := range s.PriceSummaries(kind) {
+			fmt.Printf("  %s | 평균 %s원 (%s원~%s원) | 평균 수강료 %s원 | 강좌 %d건(할인 %d건)\n", ps.Name, utils.FormatCommas(ps.AverageSessionFee), utils.FormatCommas(ps.MinSessionFee), utils.FormatCommas(ps.MaxSessionFee), utils.FormatCommas(ps.AverageFee), ps.LectureCount, ps.DiscountedCount)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/ics"
+	"github.com/darkkaiser/culturelecture-scrape/notify"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"os"
+	"sort"
+	"time"
+)
+
+// reminder 접수시작이 예정된 강좌
+type reminder struct {
+	lecture       lectures.Lecture
+	registerStart time.Time
+}
+
+// remindersCommand 필터링된 강좌 중에서 접수시작이 예정된 강좌를 접수시작일시 순서로 출력한다.
+// 접수시작일시는 이마트 문화센터만 제공하며, 결과를 .ics 파일로 내보내거나 접수시작이 임박한 강좌를 알림 채널로 전달할 수 있다.
+func remindersCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("reminders", flag.ExitOnError)
+	runID := fs.Uint64("run", 0, "강좌 목록을 읽어들일 스냅샷 저장소의 실행 ID(0이면 검색년도 및 검색시즌이 같은 가장 최근의 실행)")
+	scrapeNow := fs.Bool("scrape", false, "스냅샷 저장소 대신 이마트 문화센터 강좌를 새로 수집할지의 여부")
+	days := fs.Int("days", 14, "조회할 기간(일)")
+	icsFileName := fs.String("ics", "", "접수시작 일정을 저장할 .ics 파일")
+	alarm := fs.Duration("alarm", 30*time.Minute, ".ics 일정의 알람 시각(접수시작 전)")
+	notifyWithin := fs.Duration("notify", 0, "접수시작까지 남은 시간이 지정된 시간 이내인 강좌를 알림 채널로 전달한다(예:24h, 0이면 전달하지 않는다)")
+	where := fs.String("where", "", "강좌가 만족해야 하는 조건식(예:day in [토,일] && price <= 50000)")
+	utils.CheckErr(fs.Parse(args))
+
+	whereExpr := parseWhere(*where)
+
+	now := time.Now()
+
+	s := scrape.New(cfg)
+	s.SetLectures(remindersLectures(cfg, s, *runID, *scrapeNow))
+
+	s.FilterLearners(learnerProfiles(cfg), cfg.HolidayCalendar())
+	if whereExpr != nil {
+		s.Where(whereExpr)
+	}
+
+	var reminders []reminder
+	for _, lecture := range s.Lectures() {
+		if lecture.ScrapeExcluded == true {
+			continue
+		}
+
+		registerStart, ok := lecture.RegisterStartTime()
+		if ok == false || registerStart.Before(now) == true || registerStart.After(now.AddDate(0, 0, *days)) == true {
+			continue
+		}
+
+		reminders = append(reminders, reminder{lecture: lecture, registerStart: registerStart})
+	}
+	sort.SliceStable(reminders, func(i, j int) bool {
+		return reminders[i].registerStart.Before(reminders[j].registerStart)
+	})
+
+	for _, r := range reminders {
+		l := r.lecture
+		fmt.Printf("%s (%s) | %s | %s | %s %s~%s | %s | 접수기간:~%s | %s\n", l.RegisterStart, remainingText(r.registerStart.Sub(now)), l.StoreName, l.Title, l.DayOfTheWeek, l.StartTime, l.EndTime, l.Price, l.RegisterEnd, l.DetailPageUrl)
+	}
+	fmt.Printf("앞으로 %d일 이내에 접수가 시작되는 강좌는 총 %d개입니다.\n", *days, len(reminders))
+
+	if *icsFileName != "" {
+		exportRemindersICS(*icsFileName, reminders, *alarm)
+	}
+
+	if *notifyWithin > 0 {
+		notifyReminders(cfg, reminders, now, *notifyWithin)
+	}
+}
+
+// notifyReminders 접수시작까지 남은 시간이 within 이내인 강좌를 알림 채널로 전달한다.
+// 주기적으로 실행되더라도 같은 알림을 여러번 전달하지 않도록, 전달한 알림(강좌 및 접수시작일시)은 스냅샷 저장소에 기록하고 다음 실행에서 제외한다.
+func notifyReminders(cfg *config.Config, reminders []reminder, now time.Time, within time.Duration) {
+	notifier, err := notify.New(cfg.Notify)
+	utils.CheckErr(err)
+
+	var store *snapshot.Store
+	if cfg.Snapshot.Enabled == true {
+		store, err = snapshot.Open(cfg.Snapshot.Path)
+		utils.CheckErr(err)
+
+		//goland:noinspection GoUnhandledErrorResult
+		defer store.Close()
+	} else {
+		log.Printf("스냅샷 저장소를 사용하지 않도록 설정되어 있어 이미 전달한 알림을 기록할 수 없습니다(같은 알림이 다시 전달될 수 있습니다).")
+	}
+
+	var events []notify.Event
+	var keys []string
+	skipped := 0
+	for _, r := range reminders {
+		if r.registerStart.Sub(now) > within {
+			continue
+		}
+
+		key := reminderNotifyKey(r)
+		if store != nil {
+			notified, err := store.Notified(key)
+			utils.CheckErr(err)
+			if notified == true {
+				skipped++
+				continue
+			}
+		}
+
+		events = append(events, notify.Event{
+			Type:      notify.EventRegisterSoon,
+			Time:      now,
+			Lecture:   r.lecture,
+			OldStatus: r.lecture.Status,
+			NewStatus: r.lecture.Status,
+		})
+		keys = append(keys, key)
+	}
+
+	if len(events) > 0 {
+		utils.CheckErr(notifier.Notify(events))
+		if store != nil {
+			utils.CheckErr(store.MarkNotified(keys, now))
+		}
+	}
+	log.Printf("접수시작이 %s 이내인 강좌 %d건을 알림 채널로 전달하였습니다(이미 전달한 알림 %d건 제외).", within, len(events), skipped)
+}
+
+// reminderNotifyKey 접수시작 예정 알림을 구분하는 키를 반환한다.
+// 접수시작일시가 변경되면 새로운 알림으로 다시 전달한다.
+func reminderNotifyKey(r reminder) string {
+	return fmt.Sprintf("%s/%s@%s", notify.EventRegisterSoon, lectures.Key(&r.lecture), r.registerStart.Format(time.RFC3339))
+}
+
+// remindersLectures 접수시작 예정 강좌를 찾을 강좌 목록을 읽어들인다.
+func remindersLectures(cfg *config.Config, s *scrape.Scrape, runID uint64, scrapeNow bool) []lectures.Lecture {
+	if scrapeNow == true || cfg.Snapshot.Enabled == false {
+		s.SetChains([]string{config.ChainEmart})
+		utils.CheckErr(s.Scrape(searchYear, searchSeason))
+		return s.Lectures()
+	}
+
+	return snapshotLectures(cfg, runID, "-scrape 옵션으로 새로 수집하세요")
+}
+
+// snapshotLectures 스냅샷 저장소에서 지정된 실행의 강좌 목록을 읽어들인다.
+// runID가 0이면 검색년도 및 검색시즌이 같고 전체 체인을 수집한 가장 최근의 실행을 사용하며, 실행이 없으면 hint를 안내하고 실행을 중단한다.
+func snapshotLectures(cfg *config.Config, runID uint64, hint string) []lectures.Lecture {
+	store, err := snapshot.Open(cfg.Snapshot.Path)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer store.Close()
+
+	if runID == 0 {
+		runs, err := store.FullRuns()
+		utils.CheckErr(err)
+
+		for _, run := range runs {
+			if run.SearchYear == searchYear && run.SearchSeason == searchSeason {
+				runID = run.ID
+			}
+		}
+		if runID == 0 {
+			log.Fatalf("스냅샷 저장소(%s)에 %s년도 %s 실행이 존재하지 않습니다(%s)", cfg.Snapshot.Path, searchYear, searchSeason, hint)
+		}
+	}
+
+	lectureList, err := store.Lectures(runID)
+	utils.CheckErr(err)
+
+	return lectureList
+}
+
+// exportRemindersICS 접수시작 일정을 알람이 포함된 .ics 파일로 저장한다.
+func exportRemindersICS(fileName string, reminders []reminder, alarm time.Duration) {
+	var events []ics.Event
+	for _, r := range reminders {
+		l := r.lecture
+		events = append(events, ics.Event{
+			UID:         fmt.Sprintf("register/%s@culturelecture-scrape", l.ID),
+			Start:       r.registerStart,
+			End:         r.registerStart.Add(30 * time.Minute),
+			Summary:     fmt.Sprintf("[접수시작] %s %s", l.StoreName, l.Title),
+			Description: fmt.Sprintf("강의시간 : %s %s~%s (개강일 %s)\n수강료 : %s\n접수기간 : %s ~ %s", l.DayOfTheWeek, l.StartTime, l.EndTime, l.StartDate, l.Price, l.RegisterStart, l.RegisterEnd),
+			Location:    l.StoreName,
+			URL:         l.DetailPageUrl,
+			Alarm:       alarm,
+		})
+	}
+
+	f, err := os.Create(fileName)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	utils.CheckErr(ics.Write(f, "문화센터 강좌 접수시작", events))
+
+	log.Printf("접수시작 일정(%d건)을 .ics 파일(%s)로 저장하였습니다.", len(events), fileName)
+}
+
+// remainingText 남은 시간을 'D-일' 또는 '시간 분 후' 형식으로 반환한다.
+func remainingText(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("D-%d", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%d시간 %d분 후", int(d.Hours()), int(d.Minutes())%60)
+}
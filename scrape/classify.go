@@ -0,0 +1,96 @@
+package scrape
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"log"
+	"strings"
+)
+
+// categoryKeywords 활동 분류별 키워드 사전
+// 강좌그룹명 또는 강좌명에 키워드가 포함되어 있으면 해당 활동 분류로 분류되며, 먼저 나오는 활동 분류가 우선한다(예:놀이체육은 놀이가 아닌 체육으로 분류된다).
+// 키워드는 공백을 제거하고 소문자로 비교한다.
+var categoryKeywords = []struct {
+	category lectures.Category
+	keywords []string
+}{
+	{lectures.CategoryDance, []string{"발레", "댄스", "무용", "케이팝", "k-pop", "kpop", "치어", "힙합", "줌바", "dance", "ballet"}},
+	{lectures.CategoryMusic, []string{"음악", "뮤직", "피아노", "바이올린", "첼로", "우쿨렐레", "통기타", "드럼", "난타", "오카리나", "플룻", "리코더", "합창", "노래", "동요", "리듬", "뮤지컬", "music"}},
+	{lectures.CategoryArt, []string{"미술", "아트", "그림", "드로잉", "수채화", "클레이", "공예", "만들기", "종이접기", "캘리", "서예", "색칠"}},
+	{lectures.CategoryCooking, []string{"요리", "쿠킹", "베이킹", "쿠키", "케이크", "키친", "셰프", "쉐프", "디저트", "cook"}},
+	{lectures.CategorySports, []string{"체육", "스포츠", "축구", "농구", "피구", "야구", "태권도", "검도", "주짓수", "줄넘기", "수영", "인라인", "클라이밍", "배드민턴", "탁구", "테니스", "골프", "요가", "필라테스", "짐볼", "키즈짐", "발육", "sports"}},
+	{lectures.CategoryScience, []string{"과학", "실험", "코딩", "로봇", "레고", "블록", "메이커", "드론", "science"}},
+	{lectures.CategoryLanguage, []string{"영어", "파닉스", "중국어", "일본어", "스페인어", "한자", "외국어", "english"}},
+	{lectures.CategoryStudy, []string{"한글", "독서", "논술", "수학", "연산", "속독", "스피치", "웅변", "동화", "스토리텔링", "그림책"}},
+	{lectures.CategoryParentBaby, []string{"엄마", "아빠", "맘", "베이비", "아기", "영아", "까꿍", "걸음마", "오감", "촉감", "마사지", "baby", "withmom"}},
+	{lectures.CategoryPlay, []string{"놀이", "플레이", "체험", "play"}},
+}
+
+// Classify 활동 분류를 알 수 없는 강좌의 활동 분류를 지정한다.
+// 다시 분류하지 않으므로, 스냅샷 저장소 등에서 읽어들인 강좌 목록에도 여러번 호출할 수 있다.
+func (s *Scrape) Classify() {
+	counts := make(map[lectures.Category]int)
+	classifiedLectureCount := 0
+	for i := range s.lectures {
+		if s.lectures[i].Category != "" {
+			continue
+		}
+
+		s.lectures[i].Category = Classify(&s.lectures[i], s.config.Chains[s.lectures[i].Chain])
+		counts[s.lectures[i].Category]++
+		classifiedLectureCount++
+	}
+
+	if classifiedLectureCount > 0 && counts[lectures.CategoryEtc] > 0 {
+		log.Printf("총 %d개의 강좌중에서 %d개의 강좌는 활동 분류를 알 수 없어 '%s'(으)로 분류하였습니다.", classifiedLectureCount, counts[lectures.CategoryEtc], lectures.CategoryEtc.Name())
+	}
+}
+
+// Classify 강좌의 활동 분류를 반환한다.
+// 체인 설정의 강좌그룹별 활동 분류(강좌그룹 코드, 강좌그룹명 순서), 강좌그룹명의 키워드, 강좌명의 키워드 순서로 분류하며, 분류할 수 없으면 기타로 분류한다.
+func Classify(lecture *lectures.Lecture, cc config.ChainConfig) lectures.Category {
+	for _, key := range []string{lecture.GroupCode, lecture.Group} {
+		if key == "" {
+			continue
+		}
+		if v, exists := cc.Categories[key]; exists == true {
+			if category, ok := lectures.ParseCategory(v); ok == true {
+				return category
+			}
+		}
+	}
+
+	// 강좌그룹명이 대상 연령(예:엄마랑 아기랑)을 나타내면 강좌명에서 구체적인 활동 분류를 찾을 수 있으면 강좌명을 우선한다.
+	groupCategory, groupOk := matchCategoryKeywords(lecture.Group)
+	titleCategory, titleOk := matchCategoryKeywords(lecture.Title)
+	if groupOk == true && (genericCategory(groupCategory) == false || titleOk == false || genericCategory(titleCategory) == true) {
+		return groupCategory
+	}
+	if titleOk == true {
+		return titleCategory
+	}
+
+	return lectures.CategoryEtc
+}
+
+// genericCategory 구체적인 활동이 아닌 대상 또는 형식을 나타내는 활동 분류인지의 여부를 반환한다.
+func genericCategory(category lectures.Category) bool {
+	return category == lectures.CategoryParentBaby || category == lectures.CategoryPlay
+}
+
+// matchCategoryKeywords 문자열에 포함된 키워드로 활동 분류를 찾는다.
+func matchCategoryKeywords(text string) (lectures.Category, bool) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), ""))
+	if text == "" {
+		return "", false
+	}
+
+	for _, ck := range categoryKeywords {
+		for _, keyword := range ck.keywords {
+			if strings.Contains(text, keyword) == true {
+				return ck.category, true
+			}
+		}
+	}
+	return "", false
+}
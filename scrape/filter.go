@@ -0,0 +1,420 @@
+package scrape
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/age"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/expr"
+	"github.com/darkkaiser/culturelecture-scrape/holiday"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FilterEnv 필터링 규칙을 평가할 때 사용되는 수강자 및 공휴일 정보
+type FilterEnv struct {
+	BirthDate time.Time         // 수강자의 생년월일
+	Holidays  *holiday.Calendar // 공휴일 달력
+
+	scrape *Scrape
+}
+
+// FilterRule 필터링 규칙
+type FilterRule interface {
+	// Name 규칙 이름을 반환한다. 강좌가 제외된 사유로 기록된다.
+	Name() string
+
+	// Exclude 강좌가 규칙을 만족하지 않아 제외되어야 하는지의 여부와 상세 사유(예:연령 5~7세, 수강자 9세)를 반환한다.
+	Exclude(lecture *lectures.Lecture, env *FilterEnv) (bool, string)
+}
+
+type filterRule struct {
+	name    string
+	exclude func(lecture *lectures.Lecture, env *FilterEnv) (bool, string)
+}
+
+func (r *filterRule) Name() string {
+	return r.name
+}
+
+func (r *filterRule) Exclude(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+	return r.exclude(lecture, env)
+}
+
+// NewFilterRule 설정된 필터링 규칙을 생성한다.
+// 규칙은 설정 파일을 읽어들일 때 검증되므로, 지원하지 않는 규칙 유형이면 실행을 중단한다.
+func NewFilterRule(rc config.FilterRuleConfig) FilterRule {
+	r := &filterRule{name: rc.Name}
+
+	switch rc.Type {
+	case config.FilterRuleStatus:
+		include := receptionStatuses(rc.Include)
+		exclude := receptionStatuses(rc.Exclude)
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			excluded := (len(include) > 0 && include[lecture.Status] == false) || exclude[lecture.Status] == true
+			return excluded, fmt.Sprintf("접수상태 %s", lecture.Status)
+		}
+
+	case config.FilterRuleTime:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			if len(rc.Days) > 0 && matchDayOfTheWeek(lecture.DayOfTheWeek, rc.Days) == false {
+				return false, ""
+			}
+			if rc.Holidays == true && isHoliday(env.Holidays, lecture) == true {
+				return false, ""
+			}
+			if rc.TimeFrom != "" && lecture.StartTime < rc.TimeFrom {
+				return true, fmt.Sprintf("%s %s 시작(%s 이후 시작만 가능)", lecture.DayOfTheWeek, lecture.StartTime, rc.TimeFrom)
+			}
+			if rc.TimeTo != "" && lecture.EndTime > rc.TimeTo {
+				return true, fmt.Sprintf("%s %s 종료(%s 이전 종료만 가능)", lecture.DayOfTheWeek, lecture.EndTime, rc.TimeTo)
+			}
+			return false, ""
+		}
+
+	case config.FilterRuleKeyword:
+		include := compilePatterns(rc.Include)
+		exclude := compilePatterns(rc.Exclude)
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			if len(include) > 0 && matchPatterns(lecture.Title, include) == nil {
+				return true, "강좌명이 포함 조건에 맞지 않음"
+			}
+			if re := matchPatterns(lecture.Title, exclude); re != nil {
+				return true, fmt.Sprintf("강좌명이 '%s'에 해당", re)
+			}
+			return false, ""
+		}
+
+	case config.FilterRuleAge:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			return env.scrape.excludeByAge(lecture, env.BirthDate)
+		}
+
+	case config.FilterRulePrice:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			price, ok := lecture.PriceValue()
+			if ok == false || outOfRange(float64(price), rc.Min, rc.Max) == false {
+				return false, ""
+			}
+			return true, fmt.Sprintf("수강료 %s원(%s)", utils.FormatCommas(price), rangeText(rc.Min, rc.Max, "원"))
+		}
+
+	case config.FilterRuleSessionPrice:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			sessionFee, ok := lecture.SessionFeeValue()
+			if ok == false || outOfRange(float64(sessionFee), rc.Min, rc.Max) == false {
+				return false, ""
+			}
+			return true, fmt.Sprintf("1인 1회당 수강료 %s원(%s)", utils.FormatCommas(sessionFee), rangeText(rc.Min, rc.Max, "원"))
+		}
+
+	case config.FilterRuleDistance:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			if lecture.Distance <= 0 {
+				return rc.Unknown, "거리 알수없음(점포 위치를 찾을 수 없음)"
+			}
+			if outOfRange(lecture.Distance, rc.Min, rc.Max) == false {
+				return false, ""
+			}
+			return true, fmt.Sprintf("거리 %.1fkm(%s)", lecture.Distance, rangeText(rc.Min, rc.Max, "km"))
+		}
+
+	case config.FilterRuleStore:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			excluded := (len(rc.Include) > 0 && containsAny(lecture.StoreName, rc.Include) == false) || containsAny(lecture.StoreName, rc.Exclude) == true
+			return excluded, fmt.Sprintf("점포 %s", lecture.StoreName)
+		}
+
+	case config.FilterRuleGroup:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			excluded := (len(rc.Include) > 0 && containsAny(lecture.Group, rc.Include) == false) || containsAny(lecture.Group, rc.Exclude) == true
+			return excluded, fmt.Sprintf("강좌그룹 %s", lecture.Group)
+		}
+
+	case config.FilterRuleCategory:
+		include := categories(rc.Include)
+		exclude := categories(rc.Exclude)
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			excluded := (len(include) > 0 && include[lecture.Category] == false) || exclude[lecture.Category] == true
+			return excluded, fmt.Sprintf("활동 분류 %s", lecture.Category.Name())
+		}
+
+	case config.FilterRuleSessionCount:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			count := lecture.SessionCount()
+			if count <= 0 || outOfRange(float64(count), rc.Min, rc.Max) == false {
+				return false, ""
+			}
+			return true, fmt.Sprintf("강의횟수 %d회(%s)", count, rangeText(rc.Min, rc.Max, "회"))
+		}
+
+	case config.FilterRuleSessions:
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			ratio, ok := convenientSessionRatio(lecture, env.Holidays)
+			if ok == false || ratio >= rc.Min {
+				return false, ""
+			}
+			return true, fmt.Sprintf("주말, 공휴일 또는 16시 이후 강의일자 %.0f%%(최소 %.0f%%)", ratio*100, rc.Min*100)
+		}
+
+	case config.FilterRuleWhere:
+		e, err := expr.Parse(rc.Expression)
+		utils.CheckErr(err)
+		r.exclude = func(lecture *lectures.Lecture, env *FilterEnv) (bool, string) {
+			return e.Match(lecture) == false, fmt.Sprintf("조건식 %s", e)
+		}
+
+	default:
+		log.Fatalf("지원하지 않는 필터링 규칙 유형입니다(규칙:%s, 유형:%s)", rc.Name, rc.Type)
+	}
+
+	return r
+}
+
+// FilterRuleNames 설정된 필터링 규칙 이름 목록을 적용되는 순서대로 반환한다.
+func (s *Scrape) FilterRuleNames() []string {
+	var names []string
+	for _, rc := range s.config.Filter.Rules {
+		names = append(names, rc.Name)
+	}
+	return names
+}
+
+// filterRules 적용할 필터링 규칙 목록을 설정된 순서대로 반환한다.
+// names가 nil이면 사용하지 않도록 설정된 규칙을 제외한 전체 규칙을, nil이 아니면 names에 포함된 규칙만 반환한다.
+func (s *Scrape) filterRules(names []string) []FilterRule {
+	var rules []FilterRule
+	for _, rc := range s.config.Filter.Rules {
+		if (names == nil && rc.Disabled == true) || (names != nil && utils.Contains(names, rc.Name) == false) {
+			continue
+		}
+		rules = append(rules, NewFilterRule(rc))
+	}
+	return rules
+}
+
+// Filter 설정된 필터링 규칙을 적용하여 생년월일이 birthDate인 수강자가 수강할 수 없는 강좌를 제외한다.
+func (s *Scrape) Filter(birthDate time.Time, holidays *holiday.Calendar) {
+	s.FilterWith(birthDate, holidays, nil)
+}
+
+// FilterWith 이름이 names에 포함된 필터링 규칙만 적용하여 강좌를 필터링한다(names가 nil이면 설정된 전체 규칙을 적용한다).
+// 규칙은 설정된 순서대로 평가되며, 강좌가 만족하지 않은 모든 규칙이 제외 사유로 기록된다(ExcludedBy에는 처음으로 강좌를 제외한 규칙의 이름이 기록된다).
+// 이전에 필터링된 결과는 초기화되므로, 필터링 규칙을 바꿔가며 다시 실행할 수 있다.
+func (s *Scrape) FilterWith(birthDate time.Time, holidays *holiday.Calendar, names []string) {
+	rules := s.filterRules(names)
+
+	s.prepareFilter(holidays)
+
+	excludedCounts := s.applyFilterRules(rules, &FilterEnv{BirthDate: birthDate, Holidays: holidays, scrape: s})
+
+	excludedLectureCount := 0
+	for i := range s.lectures {
+		if s.lectures[i].ScrapeExcluded == true {
+			excludedLectureCount++
+		}
+	}
+
+	log.Printf("총 %d건의 문화센터 강좌중에서 %d건이 필터링되어 제외되었습니다(규칙별로 만족하지 않은 강좌 갯수 %s).", len(s.lectures), excludedLectureCount, ruleCountsText(rules, excludedCounts))
+}
+
+// prepareFilter 필터링 규칙을 평가하기 전에 강좌의 활동 분류, 수업일자, 수강료 및 거리를 채운다.
+// 수강자와 무관하므로 여러 수강자에 대해 필터링 규칙을 평가하더라도 한번만 실행한다.
+func (s *Scrape) prepareFilter(holidays *holiday.Calendar) {
+	s.Classify()
+	s.ExpandSessionDates(holidays)
+	s.NormalizePrices()
+	s.Locate()
+}
+
+// applyFilterRules 강좌에 필터링 규칙을 평가하여 제외 여부 및 제외 사유를 기록하고, 규칙별로 만족하지 않은 강좌 갯수를 반환한다.
+// 규칙은 설정된 순서대로 평가되며, 강좌가 만족하지 않은 모든 규칙이 제외 사유로 기록된다(ExcludedBy에는 처음으로 강좌를 제외한 규칙의 이름이 기록된다).
+func (s *Scrape) applyFilterRules(rules []FilterRule, env *FilterEnv) map[string]int {
+	excludedCounts := make(map[string]int)
+	for i := range s.lectures {
+		s.lectures[i].ExcludedReasons = nil
+		for _, rule := range rules {
+			if excluded, detail := rule.Exclude(&s.lectures[i], env); excluded == true {
+				s.lectures[i].ExcludedReasons = append(s.lectures[i].ExcludedReasons, lectures.ExclusionReason{Rule: rule.Name(), Detail: detail})
+				excludedCounts[rule.Name()]++
+			}
+		}
+
+		s.lectures[i].ScrapeExcluded = len(s.lectures[i].ExcludedReasons) > 0
+		s.lectures[i].ExcludedBy = ""
+		if s.lectures[i].ScrapeExcluded == true {
+			s.lectures[i].ExcludedBy = s.lectures[i].ExcludedReasons[0].Rule
+		}
+	}
+
+	return excludedCounts
+}
+
+// ruleCountsText 규칙별 강좌 갯수를 설정된 규칙 순서대로 'closed:1건, age:2건' 형식으로 반환한다.
+func ruleCountsText(rules []FilterRule, counts map[string]int) string {
+	var texts []string
+	for _, rule := range rules {
+		texts = append(texts, fmt.Sprintf("%s:%d건", rule.Name(), counts[rule.Name()]))
+	}
+	return strings.Join(texts, ", ")
+}
+
+// RuleResult 강좌에 필터링 규칙을 적용한 결과
+type RuleResult struct {
+	Rule     string // 필터링 규칙 이름
+	Type     string // 필터링 규칙 유형
+	Disabled bool   // 사용하지 않도록 설정된 규칙인지의 여부
+	Excluded bool   // 강좌가 규칙을 만족하지 않는지의 여부
+	Detail   string // 상세 사유
+}
+
+// Explain 사용하지 않도록 설정된 규칙을 포함한 전체 필터링 규칙을 강좌에 적용한 결과를 설정된 순서대로 반환한다.
+func (s *Scrape) Explain(lecture *lectures.Lecture, birthDate time.Time, holidays *holiday.Calendar) []RuleResult {
+	l := *lecture
+	if len(l.SessionDates) == 0 {
+		l.SessionDates = expandSessionDates(&l, holidays, s.config.Chains[l.Chain].SkipHolidays)
+	}
+	Locate(s.config.Geo, &l)
+
+	env := &FilterEnv{BirthDate: birthDate, Holidays: holidays, scrape: s}
+
+	var results []RuleResult
+	for _, rc := range s.config.Filter.Rules {
+		excluded, detail := NewFilterRule(rc).Exclude(&l, env)
+		results = append(results, RuleResult{Rule: rc.Name, Type: rc.Type, Disabled: rc.Disabled, Excluded: excluded, Detail: detail})
+	}
+	return results
+}
+
+// Where 조건식을 만족하지 않는 강좌를 제외한다.
+// 필터링 규칙을 적용한 이후에 적용하며, 조건식을 만족하지 않는 강좌에는 "where"가 제외 사유로 추가된다.
+func (s *Scrape) Where(e *expr.Expr) {
+	excludedLectureCount := 0
+	for i := range s.lectures {
+		if e.Match(&s.lectures[i]) == true {
+			continue
+		}
+
+		s.lectures[i].ExcludedReasons = append(s.lectures[i].ExcludedReasons, lectures.ExclusionReason{Rule: "where", Detail: fmt.Sprintf("조건식 %s", e)})
+		if s.lectures[i].ScrapeExcluded == false {
+			s.lectures[i].ScrapeExcluded = true
+			s.lectures[i].ExcludedBy = "where"
+			s.lectures[i].Learners = nil
+			excludedLectureCount++
+		}
+	}
+
+	log.Printf("조건식(%s)을 만족하지 않는 %d건의 문화센터 강좌가 제외되었습니다.", e, excludedLectureCount)
+}
+
+// excludeByAge 수강자의 개월수 및 나이가 강좌의 연령제한에 포함되지 않는지의 여부와 상세 사유를 반환한다.
+// 수강자의 나이 및 개월수는 강좌의 개강일을 기준으로 체인별 나이 계산 방식에 따라 계산된다.
+func (s *Scrape) excludeByAge(lecture *lectures.Lecture, birthDate time.Time) (bool, string) {
+	at, ok := lecture.StartDateTime()
+	if ok == false {
+		at = time.Now()
+	}
+	convention := s.ageConvention(lecture.Chain)
+
+	alType, from, to := s.extractMonthsOrAgeRange(lecture, convention, at)
+
+	if alType == AgeLimitMonths {
+		months := age.Months(birthDate, at)
+		return months < from || months > to, fmt.Sprintf("연령 %d~%d개월, 수강자 %d개월", from, to, months)
+	} else if alType == AgeLimitAge {
+		a := convention.Of(birthDate, at)
+		return a < from || a > to, fmt.Sprintf("연령 %d~%d세, 수강자 %d세(%s)", from, to, a, convention)
+	}
+
+	return false, ""
+}
+
+// isHoliday 강좌의 개강일이 공휴일인지의 여부를 반환한다.
+func isHoliday(holidays *holiday.Calendar, lecture *lectures.Lecture) bool {
+	start, ok := lecture.StartDateTime()
+	if ok == false {
+		return false
+	}
+	return holidays.IsHoliday(start)
+}
+
+// matchDayOfTheWeek 강좌의 요일이 요일 목록(예:월, 월요일)에 포함되는지의 여부를 반환한다.
+func matchDayOfTheWeek(dayOfTheWeek string, days []string) bool {
+	for _, d := range days {
+		if d != "" && strings.HasPrefix(dayOfTheWeek, d) == true {
+			return true
+		}
+	}
+	return false
+}
+
+func receptionStatuses(statuses []string) map[lectures.ReceptionStatus]bool {
+	m := make(map[lectures.ReceptionStatus]bool)
+	for _, v := range statuses {
+		status, ok := lectures.ParseReceptionStatus(v)
+		if ok == false {
+			log.Fatalf("지원하지 않는 접수상태입니다(접수상태:%s)", v)
+		}
+		m[status] = true
+	}
+	return m
+}
+
+func categories(values []string) map[lectures.Category]bool {
+	m := make(map[lectures.Category]bool)
+	for _, v := range values {
+		category, ok := lectures.ParseCategory(v)
+		if ok == false {
+			log.Fatalf("지원하지 않는 활동 분류입니다(활동 분류:%s)", v)
+		}
+		m[category] = true
+	}
+	return m
+}
+
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	var res []*regexp.Regexp
+	for _, pattern := range patterns {
+		res = append(res, regexp.MustCompile(pattern))
+	}
+	return res
+}
+
+// matchPatterns 문자열과 일치하는 첫 번째 정규표현식을 반환한다. 일치하는 정규표현식이 없으면 nil을 반환한다.
+func matchPatterns(s string, res []*regexp.Regexp) *regexp.Regexp {
+	for _, re := range res {
+		if re.MatchString(s) == true {
+			return re
+		}
+	}
+	return nil
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, v := range substrs {
+		if v != "" && strings.Contains(s, v) == true {
+			return true
+		}
+	}
+	return false
+}
+
+// outOfRange 값이 최소값 미만이거나 최대값(0이면 제한없음)을 초과하는지의 여부를 반환한다.
+func outOfRange(v float64, min float64, max float64) bool {
+	return v < min || (max > 0 && v > max)
+}
+
+// rangeText 최소값 및 최대값(0이면 제한없음)을 범위 문자열(예:최대 50,000원)로 반환한다.
+func rangeText(min float64, max float64, unit string) string {
+	switch {
+	case min > 0 && max > 0:
+		return fmt.Sprintf("%s~%s%s", utils.FormatCommas(int(min)), utils.FormatCommas(int(max)), unit)
+	case max > 0:
+		return fmt.Sprintf("최대 %s%s", utils.FormatCommas(int(max)), unit)
+	default:
+		return fmt.Sprintf("최소 %s%s", utils.FormatCommas(int(min)), unit)
+	}
+}
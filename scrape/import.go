@@ -0,0 +1,132 @@
+package scrape
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ReadFile ExportCSV 또는 ExportJSON으로 저장된 파일에서 강좌 목록을 읽어들인다.
+// 파일 형식은 확장자(.csv, .json)로 판단한다.
+func ReadFile(fileName string) ([]lectures.Lecture, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return ReadCSV(fileName)
+	case ".json":
+		return ReadJSON(fileName)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 파일 형식입니다(파일:%s, 지원형식:.csv, .json)", fileName)
+	}
+}
+
+// ReadJSON ExportJSON으로 저장된 JSON 파일에서 강좌 목록을 읽어들인다.
+func ReadJSON(fileName string) ([]lectures.Lecture, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var lectureList []lectures.Lecture
+	if err = json.Unmarshal(data, &lectureList); err != nil {
+		return nil, fmt.Errorf("JSON 파일(%s)을 읽어들이는 중에 오류가 발생하였습니다(%s)", fileName, err)
+	}
+
+	// 수강료 항목이 추가되기 전에 저장된 파일도 수강료를 계산한다.
+	for i := range lectureList {
+		lectureList[i].NormalizePrice()
+	}
+
+	return lectureList, nil
+}
+
+// ReadCSV ExportCSV로 저장된 CSV 파일에서 강좌 목록을 읽어들인다.
+// 항목은 항목명으로 찾으므로, 나중에 추가된 항목(csvOptionalHeaders)이 없는 이전 버전의 CSV 파일도 읽어들일 수 있다.
+func ReadCSV(fileName string) ([]lectures.Lecture, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV 파일(%s)을 읽어들이는 중에 오류가 발생하였습니다(%s)", fileName, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV 파일(%s)에 항목명이 존재하지 않습니다", fileName)
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		columns[strings.TrimPrefix(header, "\xEF\xBB\xBF")] = i
+	}
+	for _, header := range csvHeaders {
+		if utils.Contains(csvOptionalHeaders, header) == true {
+			continue
+		}
+		if _, exists := columns[header]; exists == false {
+			return nil, fmt.Errorf("CSV 파일(%s)에 항목(%s)이 존재하지 않습니다", fileName, header)
+		}
+	}
+
+	var lectureList []lectures.Lecture
+	for _, r := range records[1:] {
+		value := func(header string) string {
+			if i, exists := columns[header]; exists == true && i < len(r) {
+				return r[i]
+			}
+			return ""
+		}
+
+		lecture := lectures.Lecture{
+			ID:            value("강좌ID"),
+			StoreName:     value("점포"),
+			Group:         value("강좌그룹"),
+			Title:         value("강좌명"),
+			Teacher:       value("강사명"),
+			StartDate:     value("개강일"),
+			StartTime:     value("시작시간"),
+			EndTime:       value("종료시간"),
+			DayOfTheWeek:  value("요일"),
+			Price:         value("수강료"),
+			Count:         value("강좌횟수"),
+			DetailPageUrl: value("상세페이지"),
+		}
+		lecture.Category, _ = lectures.ParseCategory(value("활동분류"))
+		lecture.Fee, _, _ = lectures.ParsePrice(lecture.Price)
+		lecture.OriginalFee, _, _ = lectures.ParsePrice(value("정가"))
+		lecture.MaterialFee, _, _ = lectures.ParsePrice(value("재료비"))
+		lecture.NormalizePrice()
+		lecture.StoreAddress = value("점포주소")
+		lecture.Distance, _ = strconv.ParseFloat(value("거리(km)"), 64)
+		lecture.TravelMinutes, _ = strconv.Atoi(value("이동시간(분)"))
+		if learners := value("수강자"); learners != "" {
+			lecture.Learners = strings.Split(learners, ", ")
+		}
+		if pos := strings.Index(lecture.ID, "/"); pos != -1 {
+			lecture.Chain = lecture.ID[:pos]
+		}
+
+		// 지원하지 않는 접수상태는 '알수없음(접수상태 원문)' 형식으로 저장되어 있다.
+		status := value("접수상태")
+		if m := regexp.MustCompile(`^` + lectures.ReceptionStatusUnknown.String() + `\((.*)\)$`).FindStringSubmatch(status); m != nil {
+			lecture.Status, lecture.StatusText = lectures.ReceptionStatusUnknown, m[1]
+		} else {
+			lecture.Status, _ = lectures.ParseReceptionStatus(status)
+			lecture.StatusText = status
+		}
+
+		lectureList = append(lectureList, lecture)
+	}
+
+	return lectureList, nil
+}
@@ -0,0 +1,148 @@
+package scrape
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/holiday"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"log"
+	"strings"
+)
+
+// FilterLearners 수강자별로 필터링 규칙 및 수강자의 선호 조건(요일, 시간, 제외 문자열)을 적용하여 강좌별 수강 가능한 수강자 목록을 채운다.
+// 어떤 수강자도 수강할 수 없는 강좌는 제외되며, 수강할 수 없는 수강자별 사유가 강좌의 제외 사유 목록에 기록된다.
+// 강좌의 활동 분류, 수업일자 등은 한번만 채우고 수강자별로 필터링 규칙만 다시 평가하며, 결과는 하나의 요약으로 출력한다.
+func (s *Scrape) FilterLearners(learners []config.LearnerConfig, holidays *holiday.Calendar) {
+	rules := s.filterRules(nil)
+
+	s.prepareFilter(holidays)
+
+	learnerNames := make([][]string, len(s.lectures))
+	reasons := make([][]lectures.ExclusionReason, len(s.lectures))
+	ruleExcluded := make([]map[string]bool, len(s.lectures))
+	var learnerCounts []string
+	for _, lc := range learners {
+		s.applyFilterRules(rules, &FilterEnv{BirthDate: lc.BirthDate(), Holidays: holidays, scrape: s})
+
+		count := 0
+		for i := range s.lectures {
+			if s.lectures[i].ScrapeExcluded == true {
+				for _, r := range s.lectures[i].ExcludedReasons {
+					if ruleExcluded[i] == nil {
+						ruleExcluded[i] = make(map[string]bool)
+					}
+					ruleExcluded[i][r.Rule] = true
+
+					r.Learner = lc.Name
+					reasons[i] = append(reasons[i], r)
+				}
+				continue
+			}
+
+			if ok, detail := matchLearnerPreferences(&s.lectures[i], lc); ok == false {
+				reasons[i] = append(reasons[i], lectures.ExclusionReason{Rule: "learner", Detail: detail, Learner: lc.Name})
+				continue
+			}
+
+			learnerNames[i] = append(learnerNames[i], lc.Name)
+			count++
+		}
+
+		learnerCounts = append(learnerCounts, fmt.Sprintf("%s(%s생):%d건", lc.Name, lc.Birthday, count))
+	}
+
+	excludedCounts := make(map[string]int)
+	excludedLectureCount := 0
+	for i := range s.lectures {
+		s.lectures[i].Learners = learnerNames[i]
+		s.lectures[i].ExcludedReasons = reasons[i]
+		s.lectures[i].ScrapeExcluded = len(learnerNames[i]) == 0
+		s.lectures[i].ExcludedBy = ""
+		if s.lectures[i].ScrapeExcluded == true && len(reasons[i]) > 0 {
+			// 강좌를 제외한 규칙은 처음으로 수강할 수 없었던 수강자를 기준으로 기록한다.
+			s.lectures[i].ExcludedBy = reasons[i][0].Rule
+		}
+		if s.lectures[i].ScrapeExcluded == true {
+			excludedLectureCount++
+		}
+
+		for rule := range ruleExcluded[i] {
+			excludedCounts[rule]++
+		}
+	}
+
+	log.Printf("총 %d건의 문화센터 강좌중에서 %d건이 어떤 수강자도 수강할 수 없어 제외되었습니다(수강자별로 수강할 수 있는 강좌 갯수 %s, 규칙별로 한 명 이상의 수강자가 만족하지 않은 강좌 갯수 %s).", len(s.lectures), excludedLectureCount, strings.Join(learnerCounts, ", "), ruleCountsText(rules, excludedCounts))
+}
+
+// ExplainLearner 강좌에 전체 필터링 규칙 및 수강자의 선호 조건을 적용한 결과를 반환한다(Explain 참고).
+// 수강자의 선호 조건을 적용한 결과는 마지막에 "learner" 규칙으로 추가된다.
+func (s *Scrape) ExplainLearner(lecture *lectures.Lecture, lc config.LearnerConfig, holidays *holiday.Calendar) []RuleResult {
+	results := s.Explain(lecture, lc.BirthDate(), holidays)
+
+	ok, detail := matchLearnerPreferences(lecture, lc)
+
+	return append(results, RuleResult{Rule: "learner", Type: "preferences", Excluded: ok == false, Detail: detail})
+}
+
+// matchLearnerPreferences 강좌가 수강자의 선호 조건(요일, 시간, 제외 문자열)에 맞는지의 여부를 반환한다.
+// 선호 조건에 맞지 않으면 맞지 않는 조건을 함께 반환한다.
+func matchLearnerPreferences(lecture *lectures.Lecture, lc config.LearnerConfig) (bool, string) {
+	if len(lc.Days) > 0 {
+		matched := false
+		for _, day := range lc.Days {
+			if strings.HasPrefix(lecture.DayOfTheWeek, strings.TrimSuffix(day, "요일")) == true {
+				matched = true
+				break
+			}
+		}
+		if matched == false {
+			return false, fmt.Sprintf("%s 수업(선호 요일 %s)", lecture.DayOfTheWeek, strings.Join(lc.Days, ", "))
+		}
+	}
+
+	if lc.TimeFrom != "" && lecture.StartTime < lc.TimeFrom {
+		return false, fmt.Sprintf("%s 시작(%s 이후 시작만 가능)", lecture.StartTime, lc.TimeFrom)
+	}
+	if lc.TimeTo != "" && lecture.EndTime > lc.TimeTo {
+		return false, fmt.Sprintf("%s 종료(%s 이전 종료만 가능)", lecture.EndTime, lc.TimeTo)
+	}
+
+	for _, keyword := range lc.ExcludeKeywords {
+		if strings.Contains(lecture.Title, keyword) == true {
+			return false, fmt.Sprintf("강좌명에 '%s' 포함", keyword)
+		}
+	}
+
+	return true, ""
+}
+
+// Select 조건에 맞는 강좌만 포함하는 새로운 수집 결과를 반환한다.
+// 수강자별 파일 저장 등 수집 결과의 일부만 저장할 때 사용한다.
+func (s *Scrape) Select(match func(lecture *lectures.Lecture) bool) *Scrape {
+	selected := &Scrape{config: s.config, chains: s.chains}
+	for _, lecture := range s.lectures {
+		if match(&lecture) == true {
+			selected.lectures = append(selected.lectures, lecture)
+		}
+	}
+	return selected
+}
+
+// HasLearners 강좌가 지정된 수강자 모두가 수강할 수 있는 강좌인지의 여부를 반환하는 조건을 반환한다.
+func HasLearners(names ...string) func(lecture *lectures.Lecture) bool {
+	return func(lecture *lectures.Lecture) bool {
+		for _, name := range names {
+			found := false
+			for _, v := range lecture.Learners {
+				if v == name {
+					found = true
+					break
+				}
+			}
+			if found == false {
+				return false
+			}
+		}
+		return true
+	}
+}
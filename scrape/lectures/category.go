@@ -0,0 +1,55 @@
+package lectures
+
+// Category 체인 공통 활동 분류
+type Category string
+
+// 지원가능한 활동 분류 값
+const (
+	CategoryMusic      Category = "music"       // 음악
+	CategoryArt        Category = "art"         // 미술, 공예
+	CategoryDance      Category = "dance"       // 발레, 댄스
+	CategorySports     Category = "sports"      // 체육
+	CategoryCooking    Category = "cooking"     // 요리, 베이킹
+	CategoryScience    Category = "science"     // 과학, 코딩, 로봇
+	CategoryLanguage   Category = "language"    // 외국어
+	CategoryStudy      Category = "study"       // 독서, 논술, 한글, 수학
+	CategoryParentBaby Category = "parent-baby" // 엄마(아빠)와 함께하는 영아 활동
+	CategoryPlay       Category = "play"        // 놀이, 체험
+	CategoryEtc        Category = "etc"         // 기타
+)
+
+// Categories 지원가능한 활동 분류 목록
+var Categories = []Category{CategoryMusic, CategoryArt, CategoryDance, CategorySports, CategoryCooking, CategoryScience, CategoryLanguage, CategoryStudy, CategoryParentBaby, CategoryPlay, CategoryEtc}
+
+// categoryNames 활동 분류별 한글 이름
+var categoryNames = map[Category]string{
+	CategoryMusic:      "음악",
+	CategoryArt:        "미술",
+	CategoryDance:      "발레/댄스",
+	CategorySports:     "체육",
+	CategoryCooking:    "요리",
+	CategoryScience:    "과학",
+	CategoryLanguage:   "외국어",
+	CategoryStudy:      "학습",
+	CategoryParentBaby: "엄마랑 아기랑",
+	CategoryPlay:       "놀이",
+	CategoryEtc:        "기타",
+}
+
+// Name 활동 분류의 한글 이름을 반환한다.
+func (c Category) Name() string {
+	if name, exists := categoryNames[c]; exists == true {
+		return name
+	}
+	return string(c)
+}
+
+// ParseCategory 활동 분류 값(예:music) 또는 한글 이름(예:음악)을 활동 분류로 변환한다.
+func ParseCategory(s string) (Category, bool) {
+	for _, c := range Categories {
+		if s == string(c) || s == c.Name() {
+			return c, true
+		}
+	}
+	return "", false
+}
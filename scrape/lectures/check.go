@@ -0,0 +1,29 @@
+package lectures
+
+// Check 문화센터 사이트 구조 점검 결과
+type Check struct {
+	Name     string // 점검항목
+	Expected string // 기대값
+	Found    string // 실제값
+	Passed   bool   // 점검 통과 여부
+}
+
+// NewCheck 기대값과 실제값이 일치하는지 비교한 점검 결과를 생성한다.
+func NewCheck(name, expected, found string) Check {
+	return Check{
+		Name:     name,
+		Expected: expected,
+		Found:    found,
+		Passed:   expected == found,
+	}
+}
+
+// NewErrorCheck 점검 중에 발생한 오류(사이트 요청 실패, 응답 파싱 실패 등)를 실패한 점검 결과로 생성한다.
+func NewErrorCheck(name string, err error) Check {
+	return Check{
+		Name:     name,
+		Expected: "정상 응답",
+		Found:    err.Error(),
+		Passed:   false,
+	}
+}
@@ -0,0 +1,182 @@
+package culture
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DetailPageCache 강좌 상세페이지 캐시
+// 같은 상세페이지를 유효기간 안에 다시 요청하면 문화센터 사이트에 요청하지 않고 캐시 파일을 반환한다.
+type DetailPageCache struct {
+	dir string
+	ttl time.Duration
+}
+
+func NewDetailPageCache(dir string, ttl time.Duration) *DetailPageCache {
+	return &DetailPageCache{
+		dir: dir,
+		ttl: ttl,
+	}
+}
+
+// Get 상세페이지의 내용을 반환한다.
+func (c *DetailPageCache) Get(url string) ([]byte, error) {
+	hash := sha1.Sum([]byte(url))
+	fileName := filepath.Join(c.dir, hex.EncodeToString(hash[:])+".html")
+
+	if fi, err := os.Stat(fileName); err == nil && time.Since(fi.ModTime()) < c.ttl {
+		return os.ReadFile(fileName)
+	}
+
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("request failed with Status: %d", res.StatusCode)
+	}
+
+	resBodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(c.dir, 0755); err != nil {
+		return nil, err
+	}
+	if err = writeFileAtomic(fileName, resBodyBytes); err != nil {
+		return nil, err
+	}
+
+	return resBodyBytes, nil
+}
+
+// writeFileAtomic 같은 디렉토리의 임시 파일에 저장한 후 파일 이름을 변경한다.
+// 저장 중에 중단되더라도 내용이 잘린 캐시 파일이 유효한 캐시로 남지 않는다.
+func writeFileAtomic(fileName string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(fileName), filepath.Base(fileName)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpFileName := f.Name()
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpFileName)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(tmpFileName)
+		return err
+	}
+	if err = os.Chmod(tmpFileName, 0644); err != nil {
+		_ = os.Remove(tmpFileName)
+		return err
+	}
+
+	if err = os.Rename(tmpFileName, fileName); err != nil {
+		_ = os.Remove(tmpFileName)
+		return err
+	}
+	return nil
+}
+
+// 상세페이지 항목명
+var (
+	detailCurriculumLabels   = []string{"커리큘럼", "강의계획", "수업계획", "강좌내용", "수업내용", "강의내용"}
+	detailTargetAgeLabels    = []string{"수강대상", "강좌대상", "대상연령", "수강연령", "대상"}
+	detailMaterialsLabels    = []string{"준비물", "재료"}
+	detailClassroomLabels    = []string{"강의실", "강의장소", "수업장소", "장소"}
+	detailSessionDatesLabels = []string{"강의일자", "강의일정", "수업일자", "수업일정", "강좌일정"}
+)
+
+// enrichLectureFromDetailDocument 상세페이지에서 항목명(th, dt 또는 '항목명 : 항목값' 형식의 문단)을 찾아 항목값을 강좌에 채운다.
+// 문화센터마다 상세페이지의 구조가 다르므로 CSS셀렉터 대신 항목명으로 항목값을 찾는다.
+func enrichLectureFromDetailDocument(lecture *lectures.Lecture, doc *goquery.Selection) {
+	values := make(map[string]string)
+
+	// 표 형식 : <th>항목명</th><td>항목값</td>, <dt>항목명</dt><dd>항목값</dd>
+	doc.Find("th, dt").Each(func(i int, s *goquery.Selection) {
+		label := strings.ReplaceAll(utils.CleanString(s.Text()), " ", "")
+		if _, exists := values[label]; exists == false {
+			values[label] = utils.CleanString(s.Next().Text())
+		}
+	})
+
+	// 문단 형식 : 항목명 : 항목값
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		pos := strings.IndexAny(line, ":：")
+		if pos == -1 {
+			continue
+		}
+
+		label := strings.Trim(strings.ReplaceAll(utils.CleanString(line[:pos]), " ", ""), "[]■□●○◆◇▶-*")
+		if _, exists := values[label]; exists == false {
+			values[label] = utils.CleanString(strings.TrimLeft(line[pos:], ":："))
+		}
+	}
+
+	lookup := func(labels []string) string {
+		for _, label := range labels {
+			if v := values[label]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	if v := lookup(detailCurriculumLabels); v != "" {
+		lecture.Curriculum = v
+	}
+	if v := lookup(detailTargetAgeLabels); v != "" {
+		lecture.TargetAge = v
+	}
+	if v := lookup(detailMaterialsLabels); v != "" {
+		lecture.Materials = v
+	}
+	if v := lookup(detailClassroomLabels); v != "" {
+		lecture.Classroom = v
+	}
+	if v := lookup(detailSessionDatesLabels); v != "" {
+		lecture.SessionDates = extractSessionDates(v)
+	} else if len(lecture.SessionDates) == 0 {
+		lecture.SessionDates = extractSessionDates(lecture.Curriculum)
+	}
+}
+
+// extractSessionDates 문자열에 포함된 날짜(YYYY.MM.DD, YYYY-MM-DD, YYYY/MM/DD)를 중복없이 YYYY-MM-DD 형식으로 추출한다.
+func extractSessionDates(s string) []string {
+	exists := make(map[string]bool)
+
+	var dates []string
+	for _, m := range regexp.MustCompile(`([0-9]{4})[.\-/]\s?([0-9]{1,2})[.\-/]\s?([0-9]{1,2})`).FindAllStringSubmatch(s, -1) {
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+
+		date := fmt.Sprintf("%s-%02d-%02d", m[1], month, day)
+		if exists[date] == false {
+			exists[date] = true
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	return dates
+}
@@ -0,0 +1,46 @@
+package culture
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDetailPageCacheGet(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("<html>상세페이지</html>"))
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "detail")
+	c := NewDetailPageCache(dir, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		data, err := c.Get(srv.URL + "/Lecture/Detail?id=1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "<html>상세페이지</html>" {
+			t.Errorf("Get() = %q", data)
+		}
+	}
+
+	// 유효기간 안에 다시 요청하면 캐시 파일을 반환한다.
+	if requests != 1 {
+		t.Errorf("요청 횟수 = %d, want 1", requests)
+	}
+
+	// 캐시 디렉토리에는 임시 파일이 남지 않는다.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || filepath.Ext(entries[0].Name()) != ".html" {
+		t.Errorf("캐시 디렉토리 = %v, want 캐시 파일 1개", entries)
+	}
+}
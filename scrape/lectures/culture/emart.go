@@ -4,15 +4,23 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/culturelecture-scrape/config"
 	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
 	"github.com/darkkaiser/culturelecture-scrape/utils"
 	"io"
 	"log"
 	"net/http"
+	"regexp"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// 한번에 검색할 강좌 갯수
+const emartLectureSearchPageSize = 20
+
 type Emart struct {
 	name           string
 	cultureBaseUrl string
@@ -22,6 +30,35 @@ type Emart struct {
 
 	storeCodeMap        map[string]string // 점포
 	lectureGroupCodeMap map[string]string // 강좌군
+
+	lectureGroupSelectors []string                            // 수집할 강좌군명 또는 연령대
+	receptionStatusMap    map[string]lectures.ReceptionStatus // 설정 파일에 지정된 접수상태 문구별 접수상태
+
+	classDetailMap sync.Map // 상세페이지별 강좌 상세정보(emartClassDetail)
+}
+
+// emartClassDetail 강좌 검색 결과에 포함된 강좌 상세정보
+// 이마트는 상세정보가 강좌 검색 결과에 포함되어 있으므로, 상세페이지를 따로 요청하지 않고 보관해 두었다가 사용한다.
+type emartClassDetail struct {
+	content   string // 강좌 소개(HTML)
+	classroom string // 강의실
+}
+
+// emartAgeBucketMap 이마트 강좌군명별 연령대
+// 이마트는 강좌군 분류에 연령 정보가 없으므로 강좌군명으로 연령대를 지정한다.
+var emartAgeBucketMap = map[string][]lectures.AgeBucket{
+	"With Mom":               {lectures.AgeBucketBaby},
+	"With mom(event)":        {lectures.AgeBucketBaby},
+	"Kids & Children":        {lectures.AgeBucketToddler, lectures.AgeBucketChild},
+	"Kids & Children(event)": {lectures.AgeBucketToddler, lectures.AgeBucketChild},
+}
+
+// emartReceptionStatusMap 이마트 강좌 상태(classStatus)별 접수상태
+var emartReceptionStatusMap = map[string]lectures.ReceptionStatus{
+	"접수중":  lectures.ReceptionStatusPossible,
+	"접수마감": lectures.ReceptionStatusClosed,
+	"정원마감": lectures.ReceptionStatusClosed,
+	"접수대기": lectures.ReceptionStatusStandBy,
 }
 
 type emartLectureSearchResultData struct {
@@ -161,14 +198,14 @@ type emartLectureGroupSearchResultData struct {
 	} `json:"data"`
 }
 
-func NewEmart(searchYear string) *Emart {
+func NewEmart(searchYear string, cc config.ChainConfig) *Emart {
 	searchYear = utils.CleanString(searchYear)
 
 	if searchYear == "" {
 		log.Fatalf("검색년도는 빈 문자열을 허용하지 않습니다(검색년도:%s)", searchYear)
 	}
 
-	return &Emart{
+	e := &Emart{
 		name: "이마트",
 
 		cultureBaseUrl: "https://www.cultureclub.emart.com",
@@ -182,41 +219,57 @@ func NewEmart(searchYear string) *Emart {
 			"900": "순천",
 		},
 
-		lectureGroupCodeMap: map[string]string{
-			"402": "With Mom",
-			"403": "With mom(event)",
-			"404": "Kids & Children",
-			"406": "Kids & Children(event)",
-		},
+		lectureGroupSelectors: cc.Groups,
+		receptionStatusMap:    cc.ReceptionStatuses(),
 	}
+	e.storeCodeMap = selectCultureLectureStores(e.name, e.storeCodeMap, cc.Stores)
+
+	return e
 }
 
-func (e *Emart) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
+func (e *Emart) Chain() string {
+	return config.ChainEmart
+}
+
+func (e *Emart) Name() string {
+	return e.name
+}
+
+func (e *Emart) ScrapeCultureLectures() ([]lectures.Lecture, error) {
 	log.Printf("%s 문화센터 강좌 수집을 시작합니다.", e.name)
 
-	// 강좌군이 유효한지 확인한다.
-	if e.validCultureLectureGroup() == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 강좌군코드 불일치)", e.name)
+	// 수집할 강좌군을 강좌군 코드로 변환한다.
+	groups, err := e.CultureLectureGroups()
+	if err != nil {
+		return nil, err
+	}
+	if e.lectureGroupCodeMap, err = selectCultureLectureGroups(e.name, groups, e.lectureGroupSelectors); err != nil {
+		return nil, err
 	}
 
 	var wait sync.WaitGroup
+	var errs firstError
 
-	c := make(chan *lectures.Lecture, 100)
-
-	// 한번에 검색할 강좌 갯수
-	const sizeOfLectureToSearch = 20
+	c := make(chan lectureResult, 100)
 
 	var count int64 = 0
 	for storeCode, storeName := range e.storeCodeMap {
 		// 점포가 유효한지 확인한다.
-		if e.validCultureLectureStore(storeCode, storeName) == false {
-			log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 점포코드 불일치:%s)", e.name, storeCode)
+		valid, err := e.validCultureLectureStore(storeCode, storeName)
+		if err != nil {
+			return nil, err
+		}
+		if valid == false {
+			return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 점포코드 불일치:%s)", e.name, storeCode)
 		}
 
 		// 불러올 전체 강좌 갯수를 구한다.
-		lsrd := e.searchCultureLecture(storeCode, e.lectureGroupCodeMap, 0, sizeOfLectureToSearch)
+		lsrd, err := e.searchCultureLecture(storeCode, e.lectureGroupCodeMap, 0, emartLectureSearchPageSize)
+		if err != nil {
+			return nil, err
+		}
 		if lsrd.Data.GetClassByFiltering.Total == 0 {
-			log.Fatalf("%s 문화센터(%s) 강좌를 수집하는 중에 전체 강좌 갯수 추출이 실패하였습니다.", e.name, storeName)
+			return nil, fmt.Errorf("%s 문화센터(%s) 강좌를 수집하는 중에 전체 강좌 갯수 추출이 실패하였습니다.", e.name, storeName)
 		}
 
 		totalLectureCount := lsrd.Data.GetClassByFiltering.Total
@@ -227,15 +280,22 @@ func (e *Emart) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
 			go func(storeCode0, storeName0 string, index0 int) {
 				defer wait.Done()
 
-				lsrd0 := e.searchCultureLecture(storeCode0, e.lectureGroupCodeMap, index0, sizeOfLectureToSearch)
+				lsrd0, err := e.searchCultureLecture(storeCode0, e.lectureGroupCodeMap, index0, emartLectureSearchPageSize)
+				if err != nil {
+					errs.Set(err)
+					return
+				}
 
 				for _, lsrld := range lsrd0.Data.GetClassByFiltering.Data {
 					atomic.AddInt64(&count, 1)
-					go e.extractCultureLecture(storeName0, lsrld, c)
+					go func(lsrld emartLectureSearchResultLectureData) {
+						lecture, err := e.extractCultureLecture(storeCode0, storeName0, lsrld)
+						c <- lectureResult{lecture: lecture, err: err}
+					}(lsrld)
 				}
 			}(storeCode, storeName, index)
 
-			index += sizeOfLectureToSearch
+			index += emartLectureSearchPageSize
 		}
 	}
 
@@ -243,18 +303,25 @@ func (e *Emart) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
 
 	var lectureList []lectures.Lecture
 	for i := int64(0); i < count; i++ {
-		lecture := <-c
-		if len(lecture.Title) > 0 {
-			lectureList = append(lectureList, *lecture)
+		r := <-c
+		if r.err != nil {
+			errs.Set(r.err)
+			continue
+		}
+		if len(r.lecture.Title) > 0 {
+			lectureList = append(lectureList, *r.lecture)
 		}
 	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
 
 	log.Printf("%s 문화센터 강좌 수집이 완료되었습니다. 총 %d개의 강좌가 수집되었습니다.", e.name, len(lectureList))
 
-	mainC <- lectureList
+	return lectureList, nil
 }
 
-func (e *Emart) searchCultureLecture(storeCode string, lectureGroupCodeMap map[string]string, startIndex, size int) *emartLectureSearchResultData {
+func (e *Emart) searchCultureLecture(storeCode string, lectureGroupCodeMap map[string]string, startIndex, size int) (*emartLectureSearchResultData, error) {
 	// 불러올 강좌군 코드 목록을 생성한다.
 	lectureGroupCodeString := ""
 	for code := range lectureGroupCodeMap {
@@ -265,119 +332,160 @@ func (e *Emart) searchCultureLecture(storeCode string, lectureGroupCodeMap map[s
 	}
 
 	var lsrd emartLectureSearchResultData
-	e.requestSite(fmt.Sprintf("{\"query\":\"query getClassByFiltering($keyword: String, $filterData: [FilterData], $sortKey: String, $from: Int, $size: Int) {\\n  getClassByFiltering(keyword: $keyword, filterData: $filterData, sortKey: $sortKey, from: $from, size: $size) {\\n    total\\n    data {\\n      PK\\n      SK\\n      instructorId\\n      classId\\n      initialClassId\\n      classStatus\\n      classStatusBO\\n      classStatusTeacher\\n      classFlag\\n      classTitle\\n      classDay\\n      classTime {\\n        startTime\\n        endTime\\n      }\\n      mainCategory {\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n      }\\n      subCategory {\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n      }\\n      mainStoreInfo {\\n        storeName\\n        storeCode\\n        storeCenter\\n      }\\n      storeInfo\\n      classroom\\n      minClassCapacity\\n      classCapacity\\n      classTimes\\n      semesterYear\\n      semester\\n      classOriginalFee\\n      classFee\\n      classMaterialFee\\n      classType\\n      channel {\\n        online\\n        offline\\n      }\\n      classDateInfo {\\n        classStartDate\\n        classEndDate\\n        classClosedDate\\n        classRegisterStartDate\\n        classRegisterEndDate\\n        classCancelStartDate\\n        classCancelEndDate\\n      }\\n      classDetail {\\n        classDetailInfo {\\n          classDetailInfoTitle\\n          classDetailInfoContent\\n        }\\n      }\\n      mainImage {\\n        bucket\\n        region\\n        key\\n      }\\n      categoryImage {\\n        bucket\\n        region\\n        key\\n      }\\n      materialCalculate {\\n        materialFee\\n      }\\n    }\\n  }\\n}\\n\",\"variables\":{\"keyword\":\"\",\"filterData\":[{\"type\":\"mainStoreInfo.storeCode\",\"data\":[\"%s\"]},{\"type\":\"subCategory\",\"data\":[%s]}],\"sortKey\":\"deadline\",\"from\":%d,\"size\":%d}}", storeCode, lectureGroupCodeString, startIndex, size), &lsrd)
+	err := e.requestSite(fmt.Sprintf("{\"query\":\"query getClassByFiltering($keyword: String, $filterData: [FilterData], $sortKey: String, $from: Int, $size: Int) {\\n  getClassByFiltering(keyword: $keyword, filterData: $filterData, sortKey: $sortKey, from: $from, size: $size) {\\n    total\\n    data {\\n      PK\\n      SK\\n      instructorId\\n      classId\\n      initialClassId\\n      classStatus\\n      classStatusBO\\n      classStatusTeacher\\n      classFlag\\n      classTitle\\n      classDay\\n      classTime {\\n        startTime\\n        endTime\\n      }\\n      mainCategory {\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n      }\\n      subCategory {\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n      }\\n      mainStoreInfo {\\n        storeName\\n        storeCode\\n        storeCenter\\n      }\\n      storeInfo\\n      classroom\\n      minClassCapacity\\n      classCapacity\\n      classTimes\\n      semesterYear\\n      semester\\n      classOriginalFee\\n      classFee\\n      classMaterialFee\\n      classType\\n      channel {\\n        online\\n        offline\\n      }\\n      classDateInfo {\\n        classStartDate\\n        classEndDate\\n        classClosedDate\\n        classRegisterStartDate\\n        classRegisterEndDate\\n        classCancelStartDate\\n        classCancelEndDate\\n      }\\n      classDetail {\\n        classDetailInfo {\\n          classDetailInfoTitle\\n          classDetailInfoContent\\n        }\\n      }\\n      mainImage {\\n        bucket\\n        region\\n        key\\n      }\\n      categoryImage {\\n        bucket\\n        region\\n        key\\n      }\\n      materialCalculate {\\n        materialFee\\n      }\\n    }\\n  }\\n}\\n\",\"variables\":{\"keyword\":\"\",\"filterData\":[{\"type\":\"mainStoreInfo.storeCode\",\"data\":[\"%s\"]},{\"type\":\"subCategory\",\"data\":[%s]}],\"sortKey\":\"deadline\",\"from\":%d,\"size\":%d}}", storeCode, lectureGroupCodeString, startIndex, size), &lsrd)
+
+	if err != nil {
+		return nil, err
+	}
 
-	return &lsrd
+	return &lsrd, nil
 }
 
-func (e *Emart) extractCultureLecture(storeName string, lsrld emartLectureSearchResultLectureData, c chan<- *lectures.Lecture) {
+func (e *Emart) extractCultureLecture(storeCode string, storeName string, lsrld emartLectureSearchResultLectureData) (*lectures.Lecture, error) {
 	// 개강일
 	startDate := lsrld.ClassDateInfo.ClassStartDate
 	if len(startDate) != 8 {
-		log.Fatalf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(개강일:%s)", e.name, storeName, startDate)
+		return nil, fmt.Errorf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(개강일:%s)", e.name, storeName, startDate)
 	}
 	startDate = fmt.Sprintf("%s-%s-%s", startDate[:4], startDate[4:6], startDate[6:])
 
+	// 종강일
+	endDate := ""
+	if v := lsrld.ClassDateInfo.ClassEndDate; len(v) >= 8 {
+		endDate = fmt.Sprintf("%s-%s-%s", v[:4], v[4:6], v[6:8])
+	}
+
 	// 시작시간, 종료시간
 	startTime := lsrld.ClassTime.StartTime
 	endTime := lsrld.ClassTime.EndTime
 	if len(startTime) != 4 || len(endTime) != 4 {
-		log.Fatalf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(시작시간:%s, 종료시간:%s)", e.name, storeName, startTime, endTime)
+		return nil, fmt.Errorf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(시작시간:%s, 종료시간:%s)", e.name, storeName, startTime, endTime)
 	}
 	startTime = fmt.Sprintf("%s:%s", startTime[:2], startTime[2:])
 	endTime = fmt.Sprintf("%s:%s", endTime[:2], endTime[2:])
 
 	// 요일
 	if len(lsrld.ClassDay) == 0 {
-		log.Fatalf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(요일이 없음)", e.name, storeName)
+		return nil, fmt.Errorf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(요일이 없음)", e.name, storeName)
 	}
 	dayOfTheWeek := lsrld.ClassDay[0]
 	if len(dayOfTheWeek) == 0 {
-		log.Fatalf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(요일:%s)", e.name, storeName, dayOfTheWeek)
+		return nil, fmt.Errorf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(요일:%s)", e.name, storeName, dayOfTheWeek)
 	}
 
 	// 강좌횟수
 	count := fmt.Sprintf("%d", lsrld.ClassTimes)
 	if len(count) == 0 {
-		log.Fatalf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(강좌 횟수:%s)", e.name, storeName, count)
+		return nil, fmt.Errorf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(강좌 횟수:%s)", e.name, storeName, count)
 	}
 
-	// 접수상태
-	var status = lectures.ReceptionStatusUnknown
-	switch lsrld.ClassStatus {
-	case "접수중":
-		status = lectures.ReceptionStatusPossible
-	case "접수마감", "정원마감":
-		status = lectures.ReceptionStatusClosed
-	case "접수대기":
-		status = lectures.ReceptionStatusStnadBy
-	default:
-		log.Fatalf("%s 문화센터(%s) 강좌 데이터 파싱이 실패하였습니다(지원하지 않는 접수상태입니다(%s)", e.name, storeName, lsrld.ClassStatus)
+	// 수강료, 정가, 재료비
+	originalFee := emartFee(lsrld.ClassOriginalFee)
+	materialFee := lsrld.MaterialCalculate.MaterialFee
+	if materialFee == 0 {
+		materialFee = emartFee(lsrld.ClassMaterialFee)
 	}
 
-	c <- &lectures.Lecture{
+	// 접수상태
+	status, _ := lookupReceptionStatus(lsrld.ClassStatus, e.receptionStatusMap, emartReceptionStatusMap)
+
+	detailPageUrl := fmt.Sprintf("%s/class/%s", e.cultureBaseUrl, lsrld.ClassID)
+	e.classDetailMap.Store(detailPageUrl, emartClassDetail{
+		content:   lsrld.ClassDetail.ClassDetailInfo.ClassDetailInfoContent,
+		classroom: lsrld.Classroom,
+	})
+
+	return &lectures.Lecture{
+		ID:             lectures.NewID(config.ChainEmart, storeCode, lsrld.ClassID),
+		Chain:          config.ChainEmart,
 		StoreName:      fmt.Sprintf("%s %s", e.name, storeName),
-		Group:          "",
+		Group:          lsrld.SubCategory.CategoryName,
+		GroupCode:      lsrld.SubCategory.CategoryCode,
 		Title:          lsrld.ClassTitle,
 		Teacher:        "",
 		StartDate:      startDate,
+		EndDate:        endDate,
 		StartTime:      startTime,
 		EndTime:        endTime,
 		DayOfTheWeek:   dayOfTheWeek + "요일",
 		Price:          fmt.Sprintf("%d", lsrld.ClassFee),
+		Fee:            lsrld.ClassFee,
+		OriginalFee:    originalFee,
+		MaterialFee:    materialFee,
 		Count:          count,
 		Status:         status,
-		DetailPageUrl:  fmt.Sprintf("%s/class/%s", e.cultureBaseUrl, lsrld.ClassID),
+		StatusText:     lsrld.ClassStatus,
+		DetailPageUrl:  detailPageUrl,
+		RegisterStart:  emartDateTime(lsrld.ClassDateInfo.ClassRegisterStartDate),
+		RegisterEnd:    emartDateTime(lsrld.ClassDateInfo.ClassRegisterEndDate),
+		CancelStart:    emartDateTime(lsrld.ClassDateInfo.ClassCancelStartDate),
+		CancelEnd:      emartDateTime(lsrld.ClassDateInfo.ClassCancelEndDate),
 		ScrapeExcluded: false,
+	}, nil
+}
+
+func (e *Emart) validCultureLectureStore(storeCode, storeName string) (bool, error) {
+	stores, err := e.cultureLectureStores()
+	if err != nil {
+		return false, err
 	}
+	return stores[storeCode] == storeName, nil
 }
 
-func (e *Emart) validCultureLectureStore(storeCode, storeName string) bool {
+// cultureLectureStores 문화센터 점포 목록을 조회하여 점포코드별 점포명을 반환한다.
+func (e *Emart) cultureLectureStores() (map[string]string, error) {
 	var ssrd emartStoreSearchResultData
-	e.requestSite("{\"query\":\"query getStoreAreaList($isAll: Boolean!) {\\n  getStoreAreaList(isAll: $isAll) {\\n    PK\\n    area\\n    storeListInfo {\\n      storeName\\n      storeCode\\n      storeCenter\\n    }\\n  }\\n}\\n\",\"variables\":{\"isAll\":false}}", &ssrd)
+	err := e.requestSite("{\"query\":\"query getStoreAreaList($isAll: Boolean!) {\\n  getStoreAreaList(isAll: $isAll) {\\n    PK\\n    area\\n    storeListInfo {\\n      storeName\\n      storeCode\\n      storeCenter\\n    }\\n  }\\n}\\n\",\"variables\":{\"isAll\":false}}", &ssrd)
+	if err != nil {
+		return nil, err
+	}
 
+	stores := make(map[string]string)
 	for _, storeArea := range ssrd.Data.GetStoreAreaList {
 		for _, store := range storeArea.StoreListInfo {
-			if store.StoreCode == storeCode && store.StoreName == storeName {
-				return true
-			}
+			stores[store.StoreCode] = store.StoreName
 		}
 	}
 
-	return false
+	return stores, nil
 }
 
-func (e *Emart) validCultureLectureGroup() bool {
+// CultureLectureGroups 강좌 카테고리 목록을 조회하여 강좌군 트리를 추출한다.
+// 연령대는 강좌군명으로 지정되며, 지정되지 않은 강좌군은 성인 강좌군으로 간주한다.
+func (e *Emart) CultureLectureGroups() ([]lectures.Group, error) {
 	var lgsrd emartLectureGroupSearchResultData
-	e.requestSite("{\"query\":\"query getCategoryList {\\n  getCategoryList {\\n    message {\\n      mainCategory {\\n        PK\\n        SK\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n        useFlag\\n        iconFileName\\n      }\\n      subCategory {\\n        PK\\n        SK\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n        useFlag\\n        iconFileName\\n        mainDisplayFlag\\n        iconFilePath {\\n          bucket\\n          filename\\n          key\\n          region\\n        }\\n      }\\n    }\\n  }\\n}\\n\",\"variables\":{}}", &lgsrd)
-
-	for lgCode, lgName := range e.lectureGroupCodeMap {
-		exist := false
-		for _, m := range lgsrd.Data.GetCategoryList.Message {
-			for _, sc := range m.SubCategory {
-				if sc.CategoryCode == lgCode && sc.CategoryName == lgName {
-					exist = true
-					break
-				}
-			}
-			if exist == true {
-				break
+	err := e.requestSite("{\"query\":\"query getCategoryList {\\n  getCategoryList {\\n    message {\\n      mainCategory {\\n        PK\\n        SK\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n        useFlag\\n        iconFileName\\n      }\\n      subCategory {\\n        PK\\n        SK\\n        mainCategoryOrder\\n        subCategoryOrder\\n        categoryCode\\n        categoryName\\n        useFlag\\n        iconFileName\\n        mainDisplayFlag\\n        iconFilePath {\\n          bucket\\n          filename\\n          key\\n          region\\n        }\\n      }\\n    }\\n  }\\n}\\n\",\"variables\":{}}", &lgsrd)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []lectures.Group
+	for _, m := range lgsrd.Data.GetCategoryList.Message {
+		for _, sc := range m.SubCategory {
+			ageBuckets, exists := emartAgeBucketMap[sc.CategoryName]
+			if exists == false {
+				ageBuckets = []lectures.AgeBucket{lectures.AgeBucketAdult}
 			}
-		}
 
-		if exist == false {
-			return false
+			groups = append(groups, lectures.Group{
+				Code:       sc.CategoryCode,
+				Name:       sc.CategoryName,
+				Category:   m.MainCategory.CategoryName,
+				AgeBuckets: ageBuckets,
+			})
 		}
 	}
 
-	return true
+	return groups, nil
 }
 
-func (e *Emart) requestSite(body string, v interface{}) {
+func (e *Emart) requestSite(body string, v interface{}) error {
 	clPageUrl := fmt.Sprintf("https://o27tfdumlrbf7jmrvql76qbhsm.appsync-api.ap-northeast-2.amazonaws.com/graphql")
 
 	req, err := http.NewRequest("POST", clPageUrl, bytes.NewBufferString(body))
-	utils.CheckErr(err)
+	if err != nil {
+		return err
+	}
 
 	req.Header.Set("Authorization", "eyJraWQiOiJMdmZXelNObFM0WEFTU2RJcytiYXJlNHl6VWNyVmNWRExqcHQyanBDNlE0PSIsImFsZyI6IlJTMjU2In0.eyJzdWIiOiJmODU2YjQxNy0wMjQ4LTQ3ZmQtYTM5Ni01OGE2NDczODA3YjUiLCJiaXJ0aGRhdGUiOiIxOTc4LTA2LTE2IiwiY3VzdG9tOm1icktleSI6ImV5SmhiR2NpT2lKSVV6STFOaUlzSW5SNWNDSTZJa3BYVkNKOS5leUpqZEcwaU9pSkRNREF3TURBd05DSXNJbk5wWkNJNkltVnRZWEowWTNWc2RDSXNJbUYxWkNJNklrRlFVQ0lzSW5WcFpDSTZJbHd2UldaMk1VMDJSM1JJYUhOU2NYSXdaMVZTZG14blBUMGlMQ0psZUhBaU9qRTJOVEk0TURJNE1EWXNJbWx6Y3lJNklrTnNkV1JOWlcxaVpYSnphR2x3SWl3aWFtRjBJam94TmpVeU56VTVOakEyTENKcWRHa2lPaUppTTJJd05UUmhaUzA0TTJVeUxUUTVaR1V0T1RnME1DMDROV1UyWWpJM05qazJaallpZlEua01kNk5HX0RhX0RvcHBUeldVMmpFSjJWLWpQRUhDUktCclhNVTRQMk42YyIsImlzcyI6Imh0dHBzOlwvXC9jb2duaXRvLWlkcC5hcC1ub3J0aGVhc3QtMi5hbWF6b25hd3MuY29tXC9hcC1ub3J0aGVhc3QtMl9FMXRsWmcxY0UiLCJjb2duaXRvOnVzZXJuYW1lIjoiQzcxNTQ3MDI1IiwiY3VzdG9tOmVjY2lkIjoiMTk2NTc0MTkiLCJvcmlnaW5fanRpIjoiNzAyMTU1NWMtYTNhZS00YmI4LWFiNWEtYjFjMjhmMGUzY2Y5IiwiYXVkIjoiMWIwbTc2bXF1amtxczBtZDRsbGllaTQwMzIiLCJldmVudF9pZCI6ImYxNjc5OTZjLWMzNzgtNGJkMi04MDJjLTViNGNjYmMyMjkwNyIsInRva2VuX3VzZSI6ImlkIiwiYXV0aF90aW1lIjoxNjUyNzU5NjA3LCJuYW1lIjoi7Y647KeE7Zy0IiwiZXhwIjoxNjUyNzYzMjA3LCJpYXQiOjE2NTI3NTk2MDcsImp0aSI6ImI3YWYyMzk4LTUyYmItNDcxZi1hZDE4LTk5NWNiZjEzYWFiYyJ9.W7kO5Nui-bgUEQfbkbMgSYlwS-S4oyFs67CWKJlpkcDDP2JaLGN-kcPTOMT5J1Y8dHPNPc6LVXvj7XO2FdGUBNACl1NoTzkhV8d-UJUqDbWWAWRLwc0-v2ZFsX9NAMuM1oy4CrDnWzo02IEgfaj-r80ClaqZcoT969IJ5UMan7F_WtBTN1Ps6jYdI3n8arlRKSXugjJttbgGzUIjBJDFRyEqooUfeQVLFl0sY-70Jw2C_Xr4ywQYxTYymBb_H3q8CjCmU_jX1vQfFeSZwJ7wriGgonhzj0AOiQoyDrXsk88G9WT2PpbcjpoXq1wnJvibfev7N3AQlAkbdsZ6osNOsg")
 	req.Header.Set("origin", e.cultureBaseUrl)
@@ -389,15 +497,143 @@ func (e *Emart) requestSite(body string, v interface{}) {
 
 	client := &http.Client{}
 	res, err := client.Do(req)
-	utils.CheckErr(err)
-	utils.CheckStatusCode(res)
+	if err != nil {
+		return err
+	}
 
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	if err = utils.StatusCodeError(res); err != nil {
+		return err
+	}
+
 	resBodyBytes, err := io.ReadAll(res.Body)
-	utils.CheckErr(err)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resBodyBytes, v)
+}
+
+// Doctor 문화센터 사이트의 구조가 수집기가 기대하는 구조와 일치하는지 점검한다.
+func (e *Emart) Doctor() []lectures.Check {
+	var checks []lectures.Check
+
+	// 점포
+	if stores, err := e.cultureLectureStores(); err != nil {
+		checks = append(checks, lectures.NewErrorCheck("점포 목록", err))
+	} else {
+		for storeCode, storeName := range e.storeCodeMap {
+			checks = append(checks, lectures.NewCheck(fmt.Sprintf("점포(%s)", storeCode), storeName, stores[storeCode]))
+		}
+	}
+
+	// 강좌군
+	groups, err := e.CultureLectureGroups()
+	if err != nil {
+		return append(checks, lectures.NewErrorCheck("강좌군 트리", err))
+	}
+	groupChecks, lectureGroupCodeMap := checkCultureLectureGroups(groups, e.lectureGroupSelectors)
+	checks = append(checks, groupChecks...)
 
-	err = json.Unmarshal(resBodyBytes, v)
-	utils.CheckErr(err)
+	// 지원하는 강좌 상태
+	var knownStatuses []string
+	for classStatus := range emartReceptionStatusMap {
+		knownStatuses = append(knownStatuses, classStatus)
+	}
+	for classStatus := range e.receptionStatusMap {
+		knownStatuses = append(knownStatuses, classStatus)
+	}
+
+	for storeCode, storeName := range e.storeCodeMap {
+		// 전체 강좌 갯수
+		lsrd, err := e.searchCultureLecture(storeCode, lectureGroupCodeMap, 0, emartLectureSearchPageSize)
+		if err != nil {
+			checks = append(checks, lectures.NewErrorCheck(fmt.Sprintf("%s 전체 강좌 갯수(getClassByFiltering.total)", storeName), err))
+			continue
+		}
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 전체 강좌 갯수(getClassByFiltering.total)", storeName),
+			Expected: "1개 이상",
+			Found:    fmt.Sprintf("%d개", lsrd.Data.GetClassByFiltering.Total),
+			Passed:   lsrd.Data.GetClassByFiltering.Total > 0,
+		})
+
+		invalidDateCount := 0
+		unknownStatuses := make(map[string]bool)
+		for _, lsrld := range lsrd.Data.GetClassByFiltering.Data {
+			if len(lsrld.ClassDateInfo.ClassStartDate) != 8 || len(lsrld.ClassTime.StartTime) != 4 || len(lsrld.ClassTime.EndTime) != 4 || len(lsrld.ClassDay) == 0 {
+				invalidDateCount++
+			}
+			if _, known := lookupReceptionStatus(lsrld.ClassStatus, e.receptionStatusMap, emartReceptionStatusMap); known == false {
+				unknownStatuses[lsrld.ClassStatus] = true
+			}
+		}
+
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 개강일/시간/요일 형식", storeName),
+			Expected: "YYYYMMDD, hhmm, 요일 1개 이상",
+			Found:    fmt.Sprintf("불일치 %d건", invalidDateCount),
+			Passed:   invalidDateCount == 0,
+		})
+		checks = append(checks, checkReceptionStatuses(fmt.Sprintf("%s 강좌 상태(classStatus)", storeName), knownStatuses, unknownStatuses))
+	}
+
+	return checks
+}
+
+// EnrichCultureLecture 강좌 검색 결과에 포함된 상세정보로 강좌의 커리큘럼, 수강대상, 준비물, 강의실, 강의일자를 채운다.
+func (e *Emart) EnrichCultureLecture(lecture *lectures.Lecture, cache *DetailPageCache) error {
+	v, exists := e.classDetailMap.Load(lecture.DetailPageUrl)
+	if exists == false {
+		return fmt.Errorf("강좌 상세정보가 존재하지 않습니다(상세페이지:%s)", lecture.DetailPageUrl)
+	}
+	classDetail := v.(emartClassDetail)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(classDetail.content))
+	if err != nil {
+		return err
+	}
+
+	enrichLectureFromDetailDocument(lecture, doc.Selection)
+
+	if classDetail.classroom != "" {
+		lecture.Classroom = utils.CleanString(classDetail.classroom)
+	}
+
+	return nil
+}
+
+// emartFee 이마트 문화센터 사이트의 금액(숫자, 숫자 문자열 또는 null)을 숫자로 변환한다. 변환할 수 없으면 0을 반환한다.
+func emartFee(v interface{}) int {
+	switch fee := v.(type) {
+	case float64:
+		return int(fee)
+	case string:
+		if amount, _, ok := lectures.ParsePrice(fee); ok == true {
+			return amount
+		}
+	}
+	return 0
+}
+
+// emartDateTime 이마트 문화센터 사이트의 일시(YYYYMMDD, YYYYMMDDhhmm, YYYYMMDDhhmmss 또는 구분자가 포함된 형식)를 YYYY-MM-DD hh:mm 형식으로 변환한다.
+// 시각이 없으면 00:00으로 변환하며, 변환할 수 없으면 빈 문자열을 반환한다.
+func emartDateTime(s string) string {
+	digits := regexp.MustCompile(`[^0-9]`).ReplaceAllString(s, "")
+	switch len(digits) {
+	case 8:
+		digits += "0000"
+	case 12, 14:
+		digits = digits[:12]
+	default:
+		return ""
+	}
+
+	t, err := time.ParseInLocation("200601021504", digits, time.Local)
+	if err != nil {
+		return ""
+	}
+	return t.Format(lectures.DateTimeLayout)
 }
@@ -0,0 +1,81 @@
+package culture
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"sort"
+	"strings"
+)
+
+// selectCultureLectureGroups 문화센터 사이트에서 추출한 강좌군 목록에서 설정된 강좌군을 선택한다.
+// 선택한 강좌군이 하나라도 존재하지 않으면 사이트 구조가 변경된 것으로 판단하고 오류를 반환한다.
+func selectCultureLectureGroups(name string, groups []lectures.Group, selectors []string) (map[string]string, error) {
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 강좌군 목록 추출 실패)", name)
+	}
+
+	selected, unmatched := lectures.SelectGroups(groups, selectors)
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 강좌군 불일치:%s)", name, strings.Join(unmatched, ", "))
+	}
+
+	lectureGroupCodeMap := make(map[string]string)
+	for _, g := range selected {
+		lectureGroupCodeMap[g.Code] = g.Name
+	}
+
+	return lectureGroupCodeMap, nil
+}
+
+// checkCultureLectureGroups 설정된 강좌군이 문화센터 사이트의 강좌군 트리에 존재하는지 점검한다.
+// 점검 결과와 함께 선택된 강좌군 코드 목록을 반환하므로, 일부 강좌군이 존재하지 않더라도 이어서 점검할 수 있다.
+func checkCultureLectureGroups(groups []lectures.Group, selectors []string) ([]lectures.Check, map[string]string) {
+	checks := []lectures.Check{{
+		Name:     "강좌군 트리",
+		Expected: "1개 이상",
+		Found:    fmt.Sprintf("%d개", len(groups)),
+		Passed:   len(groups) > 0,
+	}}
+
+	lectureGroupCodeMap := make(map[string]string)
+	for _, selector := range selectors {
+		selected, _ := lectures.SelectGroups(groups, []string{selector})
+
+		var names []string
+		for _, g := range selected {
+			names = append(names, fmt.Sprintf("%s(%s)", g.Name, g.Code))
+			lectureGroupCodeMap[g.Code] = g.Name
+		}
+
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("강좌군(%s)", selector),
+			Expected: selector,
+			Found:    strings.Join(names, ", "),
+			Passed:   len(selected) > 0,
+		})
+	}
+
+	return checks, lectureGroupCodeMap
+}
+
+// checkReceptionStatuses 수집된 접수상태 문구 중에서 지원하지 않는 접수상태 문구가 있는지 점검한다.
+func checkReceptionStatuses(name string, known []string, unknown map[string]bool) lectures.Check {
+	var unknownStatuses []string
+	for status := range unknown {
+		unknownStatuses = append(unknownStatuses, status)
+	}
+	sort.Strings(known)
+	sort.Strings(unknownStatuses)
+
+	check := lectures.Check{
+		Name:     name,
+		Expected: strings.Join(known, ", "),
+		Found:    "지원하는 접수상태만 존재",
+		Passed:   len(unknownStatuses) == 0,
+	}
+	if len(unknownStatuses) > 0 {
+		check.Found = fmt.Sprintf("지원하지 않는 접수상태:%s", strings.Join(unknownStatuses, ", "))
+	}
+
+	return check
+}
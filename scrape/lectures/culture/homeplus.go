@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/culturelecture-scrape/config"
 	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
 	"github.com/darkkaiser/culturelecture-scrape/utils"
 	"io"
@@ -24,8 +25,12 @@ type Homeplus struct {
 	name           string
 	cultureBaseUrl string
 
-	storeCodeMap        map[string]string // 점포
-	lectureGroupCodeMap map[string]string // 강좌군
+	storeCodeMap        map[string]string            // 점포
+	storeInfoMap        map[string]homeplusStoreInfo // 점포코드별 점포 주소 및 전화번호(점포 목록 조회시 채워진다)
+	lectureGroupCodeMap map[string]string            // 강좌군
+
+	lectureGroupSelectors []string                            // 수집할 강좌군명 또는 연령대
+	receptionStatusMap    map[string]lectures.ReceptionStatus // 설정 파일에 지정된 접수상태 문구별 접수상태
 }
 
 type homeplusStoreSearchResult struct {
@@ -67,8 +72,35 @@ type homeplusStoreSearchResult struct {
 	} `json:"Data"`
 }
 
-func NewHomeplus() *Homeplus {
-	return &Homeplus{
+// homeplusStoreInfo 홈플러스 점포 목록에서 제공하는 점포 정보
+type homeplusStoreInfo struct {
+	address string
+	phone   string
+}
+
+// homeplusAgeBucketMap 홈플러스 강좌대상 코드별 연령대
+var homeplusAgeBucketMap = map[string]lectures.AgeBucket{
+	"BB": lectures.AgeBucketBaby,
+	"IF": lectures.AgeBucketToddler,
+	"EL": lectures.AgeBucketChild,
+	"MH": lectures.AgeBucketChild,
+}
+
+// homeplusReceptionStatusMap 홈플러스 장바구니 아이콘 및 문구별 접수상태
+var homeplusReceptionStatusMap = map[string]map[string]lectures.ReceptionStatus{
+	"/images/ico/icon_cart_3.png": {
+		"대기":         lectures.ReceptionStatusStandBy,
+		"강의 장바구니 담기": lectures.ReceptionStatusPossible,
+	},
+	"/images/ico/icon_cart_4.png": {
+		"마감": lectures.ReceptionStatusClosed,
+		"방문": lectures.ReceptionStatusVisitConsultation,
+		"문의": lectures.ReceptionStatusVisitInquiry,
+	},
+}
+
+func NewHomeplus(cc config.ChainConfig) *Homeplus {
+	h := &Homeplus{
 		name: "홈플러스",
 
 		cultureBaseUrl: "https://mschool.homeplus.co.kr",
@@ -78,39 +110,62 @@ func NewHomeplus() *Homeplus {
 			"0030": "순천점",
 		},
 
-		lectureGroupCodeMap: map[string]string{
-			"MH|EL|IF": "Kids 전체",
-			"BB":       "Baby 전체",
-		},
+		lectureGroupSelectors: cc.Groups,
+		receptionStatusMap:    cc.ReceptionStatuses(),
 	}
+	h.storeCodeMap = selectCultureLectureStores(h.name, h.storeCodeMap, cc.Stores)
+
+	return h
+}
+
+func (h *Homeplus) Chain() string {
+	return config.ChainHomeplus
+}
+
+func (h *Homeplus) Name() string {
+	return h.name
 }
 
-func (h *Homeplus) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
+func (h *Homeplus) ScrapeCultureLectures() ([]lectures.Lecture, error) {
 	log.Printf("%s 문화센터 강좌 수집을 시작합니다.", h.name)
 
 	// 점포가 유효한지 확인한다.
-	if h.validCultureLectureStore() == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(점포코드 불일치)", h.name)
+	valid, err := h.validCultureLectureStore()
+	if err != nil {
+		return nil, err
+	}
+	if valid == false {
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(점포코드 불일치)", h.name)
+	}
+	// 수집할 강좌군을 강좌군 코드로 변환한다.
+	groups, err := h.CultureLectureGroups()
+	if err != nil {
+		return nil, err
 	}
-	// 강좌군이 유효한지 확인한다.
-	if h.validCultureLectureGroup() == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 강좌군코드 불일치)", h.name)
+	if h.lectureGroupCodeMap, err = selectCultureLectureGroups(h.name, groups, h.lectureGroupSelectors); err != nil {
+		return nil, err
 	}
 
 	var wait sync.WaitGroup
+	var errs firstError
 
-	c := make(chan *lectures.Lecture, 100)
+	c := make(chan lectureResult, 100)
 
 	var totalExtractionLectureCount int64 = 0
 	for storeCode, storeName := range h.storeCodeMap {
 		// 불러올 전체 강좌 갯수를 구한다.
-		_, doc := h.cultureLecturePageDocument(1, storeCode, storeName)
+		_, doc, err := h.cultureLecturePageDocument(1, storeCode, storeName)
+		if err != nil {
+			return nil, err
+		}
 		value := doc.Find("#divTotalCnt").Text()
 		if len(value) == 0 {
-			log.Fatalf("%s 문화센터 강좌를 수집하는 중에 전체 강좌 갯수 추출이 실패하였습니다.", h.name)
+			return nil, fmt.Errorf("%s 문화센터 강좌를 수집하는 중에 전체 강좌 갯수 추출이 실패하였습니다.", h.name)
 		}
 		totalLectureCount, err := strconv.Atoi(value)
-		utils.CheckErr(err)
+		if err != nil {
+			return nil, err
+		}
 
 		// 불러올 전체 페이지 갯수를 구한다.
 		totalPageCount := int(math.Ceil(float64(totalLectureCount) / homeplusLectureSearchPageSize))
@@ -121,12 +176,19 @@ func (h *Homeplus) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
 			go func(storeCode string, storeName string, pageNo int) {
 				defer wait.Done()
 
-				clPageUrl, doc := h.cultureLecturePageDocument(pageNo, storeCode, storeName)
+				clPageUrl, doc, err := h.cultureLecturePageDocument(pageNo, storeCode, storeName)
+				if err != nil {
+					errs.Set(err)
+					return
+				}
 
 				clSelection := doc.Find("li > div.result_info_wrap")
 				clSelection.Each(func(i int, s *goquery.Selection) {
 					atomic.AddInt64(&totalExtractionLectureCount, 1)
-					go h.extractCultureLecture(clPageUrl, storeName, s, c)
+					go func() {
+						lecture, err := h.extractCultureLecture(clPageUrl, storeCode, storeName, s)
+						c <- lectureResult{lecture: lecture, err: err}
+					}()
 				})
 			}(storeCode, storeName, pageNo)
 		}
@@ -136,18 +198,25 @@ func (h *Homeplus) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
 
 	var lectureList []lectures.Lecture
 	for i := int64(0); i < totalExtractionLectureCount; i++ {
-		lecture := <-c
-		if len(lecture.Title) > 0 {
-			lectureList = append(lectureList, *lecture)
+		r := <-c
+		if r.err != nil {
+			errs.Set(r.err)
+			continue
+		}
+		if len(r.lecture.Title) > 0 {
+			lectureList = append(lectureList, *r.lecture)
 		}
 	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
 
 	log.Printf("%s 문화센터 강좌 수집이 완료되었습니다. 총 %d개의 강좌가 수집되었습니다.", h.name, len(lectureList))
 
-	mainC <- lectureList
+	return lectureList, nil
 }
 
-func (h *Homeplus) cultureLecturePageDocument(pageNo int, storeCode, storeName string) (string, *goquery.Document) {
+func (h *Homeplus) cultureLecturePageDocument(pageNo int, storeCode, storeName string) (string, *goquery.Document, error) {
 	clPageUrl := fmt.Sprintf("%s/Lecture/GetSearchResult", h.cultureBaseUrl)
 
 	var paramIdx = 0
@@ -163,19 +232,28 @@ func (h *Homeplus) cultureLecturePageDocument(pageNo int, storeCode, storeName s
 
 	reqBody := bytes.NewBufferString(reqBodyString)
 	res, err := http.Post(clPageUrl, "application/x-www-form-urlencoded; charset=UTF-8", reqBody)
-	utils.CheckErr(err)
-	utils.CheckStatusCode(res)
+	if err != nil {
+		return clPageUrl, nil, err
+	}
 
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	if err = utils.StatusCodeError(res); err != nil {
+		return clPageUrl, nil, err
+	}
+
 	resBodyBytes, err := io.ReadAll(res.Body)
-	utils.CheckErr(err)
+	if err != nil {
+		return clPageUrl, nil, err
+	}
 
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(resBodyBytes)))
-	utils.CheckErr(err)
+	if err != nil {
+		return clPageUrl, nil, err
+	}
 
-	return clPageUrl, doc
+	return clPageUrl, doc, nil
 }
 
 func (h *Homeplus) generateLectureSearchParamString(paramIdx int, id, txt, storeCode, lectureGroupCode string) string {
@@ -202,7 +280,7 @@ func (h *Homeplus) generateLectureSearchParamString(paramIdx int, id, txt, store
 	return b.String()
 }
 
-func (h *Homeplus) extractCultureLecture(clPageUrl string, storeName string, s *goquery.Selection, c chan<- *lectures.Lecture) {
+func (h *Homeplus) extractCultureLecture(clPageUrl string, storeCode string, storeName string, s *goquery.Selection) (*lectures.Lecture, error) {
 	// 강좌 그룹
 	title1 := utils.CleanString(s.Find("div.title_1").Text())
 	// 강좌명
@@ -212,7 +290,7 @@ func (h *Homeplus) extractCultureLecture(clPageUrl string, storeName string, s *
 
 	ls := s.Find("div.info_5")
 	if ls.Length() != 3 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌 컬럼 개수 불일치:%d, URL:%s)", h.name, ls.Length(), clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌 컬럼 개수 불일치:%d, URL:%s)", h.name, ls.Length(), clPageUrl)
 	}
 	// 강좌횟수/수강료, 형식 : 1회 6,000원
 	info5Idx0 := utils.CleanString(ls.Eq(0).Text())
@@ -223,34 +301,40 @@ func (h *Homeplus) extractCultureLecture(clPageUrl string, storeName string, s *
 
 	// 강좌그룹
 	if len(title1) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌 그룹명이 빈 문자열입니다, URL:%s)", h.name, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌 그룹명이 빈 문자열입니다, URL:%s)", h.name, clPageUrl)
 	}
 	group := title1
 
 	// 강좌명
 	if len(title2) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌명이 빈 문자열입니다, URL:%s)", h.name, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌명이 빈 문자열입니다, URL:%s)", h.name, clPageUrl)
 	}
 	title := title2
 
 	// 강사
 	teacher := utils.CleanString(regexp.MustCompile("^(.)*강사").FindString(info5Idx2))
 	if len(teacher) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx2, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx2, clPageUrl)
 	}
 
 	// 개강일
 	startDate := utils.CleanString(regexp.MustCompile("[0-9]{4}.[0-9]{2}.[0-9]{2} ~").FindString(info5Idx1))
 	if len(startDate) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx1, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx1, clPageUrl)
 	}
 	startDate = strings.ReplaceAll(startDate[:len(startDate)-2], ".", "-")
 
+	// 종강일
+	endDate := regexp.MustCompile("~ ?[0-9]{4}.[0-9]{2}.[0-9]{2}").FindString(info5Idx1)
+	if len(endDate) > 0 {
+		endDate = strings.ReplaceAll(utils.CleanString(strings.TrimPrefix(endDate, "~")), ".", "-")
+	}
+
 	// 시작시간, 종료시간
 	startTime := regexp.MustCompile("[0-9]{2}:[0-9]{2} ~").FindString(info4)
 	endTime := regexp.MustCompile("~ [0-9]{2}:[0-9]{2}").FindString(info4)
 	if len(startTime) == 0 || len(endTime) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info4, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info4, clPageUrl)
 	}
 	startTime = utils.CleanString(startTime[:len(startTime)-1])
 	endTime = utils.CleanString(endTime[1:])
@@ -258,133 +342,285 @@ func (h *Homeplus) extractCultureLecture(clPageUrl string, storeName string, s *
 	// 요일
 	dayOfTheWeek := utils.CleanString(regexp.MustCompile("^[월화수목금토일] ").FindString(info4))
 	if len(dayOfTheWeek) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info4, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info4, clPageUrl)
 	}
 
 	// 수강료
 
+	// 수강료의 기준 인원
+	persons := lectures.ParsePricePersons(info5Idx0)
+
 	// '1회 7,000원 (2인 기준)' => '1회 7,000원'
 	info5Idx0 = utils.CleanString(regexp.MustCompile(`\s*\(\d+인 기준\)`).ReplaceAllString(info5Idx0, ""))
 
 	price := utils.CleanString(regexp.MustCompile(" [0-9]{1,3}(,[0-9]{3})*원$").FindString(info5Idx0))
 	if len(price) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx0, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx0, clPageUrl)
 	}
 
 	// 강좌횟수
 	count := utils.CleanString(regexp.MustCompile("^[0-9]{1,3}회").FindString(info5Idx0))
 	if len(count) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx0, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", h.name, info5Idx0, clPageUrl)
 	}
 
 	// 접수상태
 	classCartImgUrl, exists := s.Find("button.btn_class_cart > img").Attr("src")
 	if exists == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(접수상태 추출이 실패하였습니다, URL:%s)", h.name, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(접수상태 추출이 실패하였습니다, URL:%s)", h.name, clPageUrl)
 	}
 	classCartStatus := utils.CleanString(s.Find("button.btn_class_cart > span:last-child").Text())
 
-	var status = lectures.ReceptionStatusUnknown
-	switch classCartImgUrl {
-	case "/images/ico/icon_cart_3.png":
-		if classCartStatus == "대기" {
-			status = lectures.ReceptionStatusStnadBy
-		} else if classCartStatus == "강의 장바구니 담기" {
-			status = lectures.ReceptionStatusPossible
-		} else {
-			log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(지원하지 않는 접수상태입니다(분석데이터:%s, URL:%s)", h.name, classCartImgUrl, clPageUrl)
-		}
-	case "/images/ico/icon_cart_4.png":
-		if classCartStatus == "마감" {
-			status = lectures.ReceptionStatusClosed
-		} else if classCartStatus == "방문" {
-			status = lectures.ReceptionStatusVisitConsultation
-		} else if classCartStatus == "문의" {
-			status = lectures.ReceptionStatusVisitInquiry
-		} else {
-			log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(지원하지 않는 접수상태입니다(분석데이터:%s, URL:%s)", h.name, classCartImgUrl, clPageUrl)
-		}
-	default:
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(지원하지 않는 접수상태입니다(분석데이터:%s, URL:%s)", h.name, classCartImgUrl, clPageUrl)
-	}
+	status, _ := lookupReceptionStatus(classCartStatus, h.receptionStatusMap, homeplusReceptionStatusMap[classCartImgUrl])
 
 	// 상세페이지로 이동하기 위한 LectureMasterID를 구한다.
 	idSelection := s.Find("input[name=LectureMasterID]")
 	lectureMasterId, exists := idSelection.Attr("value")
 	if exists == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(상세페이지로 이동하기 위해 필요한 [ LectureMasterID ] 값이 비어 있습니다, URL:%s)", h.name, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(상세페이지로 이동하기 위해 필요한 [ LectureMasterID ] 값이 비어 있습니다, URL:%s)", h.name, clPageUrl)
 	}
+	lectureMasterId = utils.CleanString(lectureMasterId)
 
-	c <- &lectures.Lecture{
+	return &lectures.Lecture{
+		ID:             lectures.NewID(config.ChainHomeplus, storeCode, lectureMasterId),
+		Chain:          config.ChainHomeplus,
 		StoreName:      fmt.Sprintf("%s %s", h.name, storeName),
+		StoreAddress:   h.storeInfoMap[storeCode].address,
+		StorePhone:     h.storeInfoMap[storeCode].phone,
 		Group:          group,
 		Title:          title,
 		Teacher:        teacher,
 		StartDate:      startDate,
+		EndDate:        endDate,
 		StartTime:      startTime,
 		EndTime:        endTime,
 		DayOfTheWeek:   fmt.Sprintf("%s요일", dayOfTheWeek),
 		Price:          price,
+		Persons:        persons,
 		Count:          count,
 		Status:         status,
-		DetailPageUrl:  fmt.Sprintf("%s/Lecture/Detail?LectureMasterID=%s", h.cultureBaseUrl, utils.CleanString(lectureMasterId)),
+		StatusText:     classCartStatus,
+		DetailPageUrl:  fmt.Sprintf("%s/Lecture/Detail?LectureMasterID=%s", h.cultureBaseUrl, lectureMasterId),
 		ScrapeExcluded: false,
+	}, nil
+}
+
+func (h *Homeplus) validCultureLectureStore() (bool, error) {
+	stores, err := h.cultureLectureStores()
+	if err != nil {
+		return false, err
+	}
+	for storeCode, storeName := range h.storeCodeMap {
+		if stores[storeCode] != storeName {
+			return false, nil
+		}
 	}
+
+	return true, nil
 }
 
-func (h *Homeplus) validCultureLectureStore() bool {
+// cultureLectureStores 문화센터 점포 목록을 조회하여 점포코드별 점포명을 반환한다.
+func (h *Homeplus) cultureLectureStores() (map[string]string, error) {
 	res, err := http.Post(fmt.Sprintf("%s/Store/GetStoreList", h.cultureBaseUrl), "application/json; charset=utf-8", nil)
-	utils.CheckErr(err)
-	utils.CheckStatusCode(res)
+	if err != nil {
+		return nil, err
+	}
 
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	if err = utils.StatusCodeError(res); err != nil {
+		return nil, err
+	}
+
 	resBodyBytes, err := io.ReadAll(res.Body)
-	utils.CheckErr(err)
+	if err != nil {
+		return nil, err
+	}
 
 	var storeSearchResult homeplusStoreSearchResult
-	err = json.Unmarshal(resBodyBytes, &storeSearchResult)
-	utils.CheckErr(err)
+	if err = json.Unmarshal(resBodyBytes, &storeSearchResult); err != nil {
+		return nil, err
+	}
 
-	for storeCode, storeName := range h.storeCodeMap {
-		foundStore := false
-		for _, elem := range storeSearchResult.Data.StoreList {
-			if storeCode == elem.StoreCode && storeName == elem.StoreName {
-				foundStore = true
-				break
-			}
-		}
-		if foundStore == false {
-			return false
+	stores := make(map[string]string)
+	h.storeInfoMap = make(map[string]homeplusStoreInfo)
+	for _, elem := range storeSearchResult.Data.StoreList {
+		stores[elem.StoreCode] = elem.StoreName
+
+		address := utils.CleanString(elem.Address1 + " " + elem.Address2)
+		if zipCode := utils.CleanString(elem.ZipCode); zipCode != "" && address != "" {
+			address = fmt.Sprintf("(%s) %s", zipCode, address)
 		}
+		h.storeInfoMap[elem.StoreCode] = homeplusStoreInfo{address: address, phone: utils.CleanString(elem.PhoneNumber)}
 	}
 
-	return true
+	return stores, nil
 }
 
-func (h *Homeplus) validCultureLectureGroup() bool {
+// CultureLectureGroups 강좌 검색 페이지의 강좌군 트리를 추출한다.
+// 각 분류의 첫번째 강좌군('전체')에만 연령대가 지정되므로, 연령대로 선택하면 해당 분류의 '전체' 강좌군이 선택된다.
+func (h *Homeplus) CultureLectureGroups() ([]lectures.Group, error) {
 	res, err := http.Get(fmt.Sprintf("%s/Lecture/Search", h.cultureBaseUrl))
-	utils.CheckErr(err)
-	utils.CheckStatusCode(res)
+	if err != nil {
+		return nil, err
+	}
 
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	if err = utils.StatusCodeError(res); err != nil {
+		return nil, err
+	}
+
 	doc, err := goquery.NewDocumentFromReader(res.Body)
-	utils.CheckErr(err)
+	if err != nil {
+		return nil, err
+	}
 
-	for lectureGroupCode, lectureGroupName := range h.lectureGroupCodeMap {
-		lectureGroupSelection := doc.Find(fmt.Sprintf("section.search_body div.menu_depth_2_wrap ul.tree_menu_2 > li.depth_2 > ul.depth_3 > li:first-child > button[data-lecture-target='%s']", lectureGroupCode))
-		if lectureGroupSelection.Length() != 1 {
-			return false
+	var groups []lectures.Group
+	doc.Find("section.search_body div.menu_depth_2_wrap ul.tree_menu_2 > li.depth_2").Each(func(i int, s *goquery.Selection) {
+		category := utils.CleanString(s.Children().Not("ul").First().Text())
+
+		s.Find("ul.depth_3 > li").Each(func(j int, ls *goquery.Selection) {
+			lectureGroupSelection := ls.ChildrenFiltered("button[data-lecture-target]")
+			if lectureGroupSelection.Length() != 1 {
+				return
+			}
+
+			lectureGroupCode, _ := lectureGroupSelection.Attr("data-lecture-target")
+
+			var ageBuckets []lectures.AgeBucket
+			if j == 0 {
+				for _, target := range strings.Split(lectureGroupCode, "|") {
+					bucket, exists := homeplusAgeBucketMap[target]
+					if exists == false {
+						bucket = lectures.AgeBucketAdult
+					}
+
+					found := false
+					for _, v := range ageBuckets {
+						if v == bucket {
+							found = true
+							break
+						}
+					}
+					if found == false {
+						ageBuckets = append(ageBuckets, bucket)
+					}
+				}
+			}
+
+			groups = append(groups, lectures.Group{
+				Code:       lectureGroupCode,
+				Name:       utils.CleanString(lectureGroupSelection.Text()),
+				Category:   category,
+				AgeBuckets: ageBuckets,
+			})
+		})
+	})
+
+	return groups, nil
+}
+
+// Doctor 문화센터 사이트의 구조가 수집기가 기대하는 구조와 일치하는지 점검한다.
+func (h *Homeplus) Doctor() []lectures.Check {
+	var checks []lectures.Check
+
+	// 점포
+	if stores, err := h.cultureLectureStores(); err != nil {
+		checks = append(checks, lectures.NewErrorCheck("점포 목록", err))
+	} else {
+		for storeCode, storeName := range h.storeCodeMap {
+			checks = append(checks, lectures.NewCheck(fmt.Sprintf("점포(%s)", storeCode), storeName, stores[storeCode]))
 		}
+	}
 
-		val := lectureGroupSelection.Text()
-		if utils.CleanString(val) != lectureGroupName {
-			return false
+	// 강좌군
+	groups, err := h.CultureLectureGroups()
+	if err != nil {
+		return append(checks, lectures.NewErrorCheck("강좌군 트리", err))
+	}
+	groupChecks, lectureGroupCodeMap := checkCultureLectureGroups(groups, h.lectureGroupSelectors)
+	checks = append(checks, groupChecks...)
+	h.lectureGroupCodeMap = lectureGroupCodeMap
+
+	// 지원하는 장바구니 아이콘 및 문구
+	var knownStatuses []string
+	for classCartImgUrl, statusMap := range homeplusReceptionStatusMap {
+		for classCartStatus := range statusMap {
+			knownStatuses = append(knownStatuses, fmt.Sprintf("%s(%s)", classCartStatus, classCartImgUrl))
 		}
 	}
+	for classCartStatus := range h.receptionStatusMap {
+		knownStatuses = append(knownStatuses, classCartStatus)
+	}
+
+	for storeCode, storeName := range h.storeCodeMap {
+		_, doc, err := h.cultureLecturePageDocument(1, storeCode, storeName)
+		if err != nil {
+			checks = append(checks, lectures.NewErrorCheck(fmt.Sprintf("%s 강좌 목록", storeName), err))
+			continue
+		}
+
+		// 전체 강좌 갯수
+		value := utils.CleanString(doc.Find("#divTotalCnt").Text())
+		_, err = strconv.Atoi(value)
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 전체 강좌 갯수(#divTotalCnt)", storeName),
+			Expected: "숫자",
+			Found:    value,
+			Passed:   err == nil,
+		})
+
+		// 강좌 목록
+		clSelection := doc.Find("li > div.result_info_wrap")
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 강좌 목록(li > div.result_info_wrap)", storeName),
+			Expected: "1개 이상",
+			Found:    fmt.Sprintf("%d개", clSelection.Length()),
+			Passed:   clSelection.Length() > 0,
+		})
+
+		columnMismatchCount := 0
+		unknownStatuses := make(map[string]bool)
+		clSelection.Each(func(i int, s *goquery.Selection) {
+			if s.Find("div.info_5").Length() != 3 {
+				columnMismatchCount++
+			}
+
+			classCartImgUrl, _ := s.Find("button.btn_class_cart > img").Attr("src")
+			classCartStatus := utils.CleanString(s.Find("button.btn_class_cart > span:last-child").Text())
+			if _, known := lookupReceptionStatus(classCartStatus, h.receptionStatusMap, homeplusReceptionStatusMap[classCartImgUrl]); known == false {
+				unknownStatuses[fmt.Sprintf("%s(%s)", classCartStatus, classCartImgUrl)] = true
+			}
+		})
+
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 강좌 컬럼(div.info_5)", storeName),
+			Expected: "모든 강좌에 3개",
+			Found:    fmt.Sprintf("불일치 %d건", columnMismatchCount),
+			Passed:   columnMismatchCount == 0,
+		})
+		checks = append(checks, checkReceptionStatuses(fmt.Sprintf("%s 접수상태 아이콘", storeName), knownStatuses, unknownStatuses))
+	}
+
+	return checks
+}
+
+// EnrichCultureLecture 강좌 상세페이지를 수집하여 강좌의 커리큘럼, 수강대상, 준비물, 강의실, 강의일자를 채운다.
+func (h *Homeplus) EnrichCultureLecture(lecture *lectures.Lecture, cache *DetailPageCache) error {
+	data, err := cache.Get(lecture.DetailPageUrl)
+	if err != nil {
+		return err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	enrichLectureFromDetailDocument(lecture, doc.Selection)
 
-	return true
+	return nil
 }
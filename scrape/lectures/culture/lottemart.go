@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/culturelecture-scrape/config"
 	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
 	"github.com/darkkaiser/culturelecture-scrape/utils"
 	"io"
@@ -22,11 +23,32 @@ type Lottemart struct {
 
 	searchTermCode string // 검색년도 & 검색시즌 코드
 
-	storeCodeMap        map[string]string            // 점포
-	lectureGroupCodeMap map[string]map[string]string // 강좌군
+	storeCodeMap        map[string]string // 점포
+	lectureGroupCodeMap map[string]string // 강좌군
+
+	lectureGroupSelectors []string                            // 수집할 강좌군명 또는 연령대
+	receptionStatusMap    map[string]lectures.ReceptionStatus // 설정 파일에 지정된 접수상태 문구별 접수상태
+}
+
+// lottemartAgeBucketMap 롯데마트 강좌군 분류 ID별 연령대
+var lottemartAgeBucketMap = map[string]lectures.AgeBucket{
+	"baby-tit":    lectures.AgeBucketBaby,    // 영아강좌(0~5세)
+	"toddler-tit": lectures.AgeBucketToddler, // 유아 강좌(5~7세)
+	"child-tit":   lectures.AgeBucketChild,   // 어린이청소년
+}
+
+// lottemartReceptionStatusMap 롯데마트 접수상태 버튼 문구별 접수상태
+var lottemartReceptionStatusMap = map[string]lectures.ReceptionStatus{
+	"바로신청":   lectures.ReceptionStatusPossible,
+	"접수마감":   lectures.ReceptionStatusClosed,
+	"대기자 신청": lectures.ReceptionStatusStandBy,
+	"현장문의":   lectures.ReceptionStatusVisitInquiry,
+	"전화문의":   lectures.ReceptionStatusTellInquiry,
+	"현장접수":   lectures.ReceptionStatusVisitInquiry,
+	"온라인마감":  lectures.ReceptionStatusOnlineClosed,
 }
 
-func NewLottemart(searchYear string, searchSeasonCode string) *Lottemart {
+func NewLottemart(searchYear string, searchSeasonCode string, cc config.ChainConfig) *Lottemart {
 	searchYear = utils.CleanString(searchYear)
 	searchSeasonCode = utils.CleanString(searchSeasonCode)
 
@@ -34,7 +56,7 @@ func NewLottemart(searchYear string, searchSeasonCode string) *Lottemart {
 		log.Fatalf("검색년도 및 검색시즌코드는 빈 문자열을 허용하지 않습니다(검색년도:%s, 검색시즌코드:%s)", searchYear, searchSeasonCode)
 	}
 
-	return &Lottemart{
+	l := &Lottemart{
 		name: "롯데마트",
 
 		cultureBaseUrl: "https://culture.lottemart.com",
@@ -45,70 +67,58 @@ func NewLottemart(searchYear string, searchSeasonCode string) *Lottemart {
 			"705": "여수점",
 		},
 
-		lectureGroupCodeMap: map[string]map[string]string{
-			"baby-tit": { // 영아강좌(0~5세)
-				"21": "음악감성",
-				"81": "",
-				"22": "미술표현",
-				"82": "",
-				"23": "언어인지",
-				"83": "",
-				"24": "통합놀이",
-				"84": "",
-				"25": "신체발달",
-				"85": "",
-				"26": "조기영재",
-				"86": "",
-				"27": "창의적체험활동",
-				"87": "",
-			},
-			"toddler-tit": { // 유아 강좌(5~7세)
-				"31": "음악 감성",
-				"32": "미술표현",
-				"33": "창의인지",
-				"34": "언어인지",
-				"35": "신체발달",
-				"36": "키즈쿠킹",
-				"37": "창의적체험활동",
-			},
-			"child-tit": { // 어린이청소년
-				"41": "음악감성",
-				"42": "미술표현",
-				"43": "창의인지",
-				"44": "진로/직업체험",
-				"45": "언어인지",
-				"46": "신체발달",
-				"47": "키즈쿠킹",
-				"48": "창의적체험활동",
-			},
-		},
+		lectureGroupSelectors: cc.Groups,
+		receptionStatusMap:    cc.ReceptionStatuses(),
 	}
+	l.storeCodeMap = selectCultureLectureStores(l.name, l.storeCodeMap, cc.Stores)
+
+	return l
+}
+
+func (l *Lottemart) Chain() string {
+	return config.ChainLottemart
+}
+
+func (l *Lottemart) Name() string {
+	return l.name
 }
 
-func (l *Lottemart) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
+func (l *Lottemart) ScrapeCultureLectures() ([]lectures.Lecture, error) {
 	log.Printf("%s 문화센터 강좌 수집을 시작합니다.", l.name)
 
-	// 강좌군이 유효한지 확인한다.
-	if l.validCultureLectureGroup() == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 강좌군코드 불일치)", l.name)
+	// 수집할 강좌군을 강좌군 코드로 변환한다.
+	groups, err := l.CultureLectureGroups()
+	if err != nil {
+		return nil, err
+	}
+	if l.lectureGroupCodeMap, err = selectCultureLectureGroups(l.name, groups, l.lectureGroupSelectors); err != nil {
+		return nil, err
 	}
 
 	var wait sync.WaitGroup
+	var errs firstError
 
-	c := make(chan *lectures.Lecture, 100)
+	c := make(chan lectureResult, 100)
 
 	var count int64 = 0
 	for storeCode, storeName := range l.storeCodeMap {
 		// 점포가 유효한지 확인한다.
-		if l.validCultureLectureStore(storeCode, storeName) == false {
-			log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 점포코드 불일치:%s)", l.name, storeCode)
+		valid, err := l.validCultureLectureStore(storeCode, storeName)
+		if err != nil {
+			return nil, err
+		}
+		if valid == false {
+			return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(CSS셀렉터를 확인하세요, 점포코드 불일치:%s)", l.name, storeCode)
 		}
 
 		// 불러올 전체 페이지 갯수를 구한다.
-		_, doc := l.cultureLecturePageDocument(1, storeCode)
+		_, doc, err := l.cultureLecturePageDocument(1, storeCode)
+		if err != nil {
+			return nil, err
+		}
 		pi, exists := doc.Find("tr:last-child").Attr("pageinfo")
 		if exists == false {
-			log.Fatalf("%s 문화센터 강좌를 수집하는 중에 전체 페이지 갯수 추출이 실패하였습니다.", l.name)
+			return nil, fmt.Errorf("%s 문화센터 강좌를 수집하는 중에 전체 페이지 갯수 추출이 실패하였습니다.", l.name)
 		}
 
 		// ---------------------------------
@@ -122,11 +132,13 @@ func (l *Lottemart) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
 		// 24 : 접수마감 갯수
 		piSplit := strings.Split(pi, "|")
 		if len(piSplit) != 6 {
-			log.Fatalf("%s 문화센터 강좌를 수집하는 중에 전체 페이지 갯수 추출이 실패하였습니다.(pageinfo:%s)", l.name, pi)
+			return nil, fmt.Errorf("%s 문화센터 강좌를 수집하는 중에 전체 페이지 갯수 추출이 실패하였습니다.(pageinfo:%s)", l.name, pi)
 		}
 
 		totalPageCount, err := strconv.Atoi(piSplit[1])
-		utils.CheckErr(err)
+		if err != nil {
+			return nil, err
+		}
 
 		// 강좌 데이터를 수집한다.
 		for pageNo := 1; pageNo <= totalPageCount; pageNo++ {
@@ -134,12 +146,19 @@ func (l *Lottemart) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
 			go func(storeCode string, storeName string, pageNo int) {
 				defer wait.Done()
 
-				clPageUrl, doc := l.cultureLecturePageDocument(pageNo, storeCode)
+				clPageUrl, doc, err := l.cultureLecturePageDocument(pageNo, storeCode)
+				if err != nil {
+					errs.Set(err)
+					return
+				}
 
 				clSelection := doc.Find("tr")
 				clSelection.Each(func(i int, s *goquery.Selection) {
 					atomic.AddInt64(&count, 1)
-					go l.extractCultureLecture(clPageUrl, storeCode, storeName, s, c)
+					go func() {
+						lecture, err := l.extractCultureLecture(clPageUrl, storeCode, storeName, s)
+						c <- lectureResult{lecture: lecture, err: err}
+					}()
 				})
 			}(storeCode, storeName, pageNo)
 		}
@@ -149,22 +168,29 @@ func (l *Lottemart) ScrapeCultureLectures(mainC chan<- []lectures.Lecture) {
 
 	var lectureList []lectures.Lecture
 	for i := int64(0); i < count; i++ {
-		lecture := <-c
-		if len(lecture.Title) > 0 {
-			lectureList = append(lectureList, *lecture)
+		r := <-c
+		if r.err != nil {
+			errs.Set(r.err)
+			continue
+		}
+		if len(r.lecture.Title) > 0 {
+			lectureList = append(lectureList, *r.lecture)
 		}
 	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
 
 	log.Printf("%s 문화센터 강좌 수집이 완료되었습니다. 총 %d개의 강좌가 수집되었습니다.", l.name, len(lectureList))
 
-	mainC <- lectureList
+	return lectureList, nil
 }
 
-func (l *Lottemart) extractCultureLecture(clPageUrl string, storeCode string, storeName string, s *goquery.Selection, c chan<- *lectures.Lecture) {
+func (l *Lottemart) extractCultureLecture(clPageUrl string, storeCode string, storeName string, s *goquery.Selection) (*lectures.Lecture, error) {
 	// 강좌의 컬럼 개수를 확인한다.
 	ls := s.Find("td")
 	if ls.Length() != 5 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌 컬럼 개수 불일치:%d, URL:%s)", l.name, ls.Length(), clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌 컬럼 개수 불일치:%d, URL:%s)", l.name, ls.Length(), clPageUrl)
 	}
 
 	// 강사명, 형식 : 김준희
@@ -179,14 +205,14 @@ func (l *Lottemart) extractCultureLecture(clPageUrl string, storeCode string, st
 	// 강좌명
 	lts := ls.Eq(0).Find("div.info-txt > a")
 	if lts.Length() == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌명 <a> 태그를 찾을 수 없습니다, URL:%s)", l.name, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(강좌명 <a> 태그를 찾을 수 없습니다, URL:%s)", l.name, clPageUrl)
 	}
 	title := utils.CleanString(lts.Text())
 
 	// 개강일
 	startDate := regexp.MustCompile("^[0-9]{4}\\.[0-9]{2}\\.[0-9]{2}").FindString(lectureCol3)
 	if len(startDate) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol3, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol3, clPageUrl)
 	}
 	startDate = strings.ReplaceAll(startDate, ".", "-")
 
@@ -194,60 +220,49 @@ func (l *Lottemart) extractCultureLecture(clPageUrl string, storeCode string, st
 	startTime := strings.TrimSpace(regexp.MustCompile(" [0-9]{2}:[0-9]{2}").FindString(lectureCol3))
 	endTime := strings.TrimSpace(regexp.MustCompile("[0-9]{2}:[0-9]{2}$").FindString(lectureCol3))
 	if len(startDate) == 0 || len(endTime) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol3, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol3, clPageUrl)
 	}
 
 	// 요일
 	dayOfTheWeek := regexp.MustCompile("\\([월화수목금토일]").FindString(lectureCol3)
 	if len(dayOfTheWeek) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol3, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol3, clPageUrl)
 	}
 	dayOfTheWeek = string([]rune(dayOfTheWeek)[1:])
 
 	// 수강료
 	price := regexp.MustCompile("[0-9,]{1,8}원$").FindString(lectureCol4)
 	if strings.Contains(price, "원") == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol4, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol4, clPageUrl)
 	}
 
+	// 정가와 할인된 수강료가 함께 표시되면(예:80,000원 60,000원) 할인된 수강료(마지막 금액)를 수강료로 사용한다.
+	fee, originalFee, _ := lectures.ParsePrice(lectureCol4)
+
 	// 강좌횟수
 	count := regexp.MustCompile("[0-9]{1,3}회").FindString(lectureCol4)
 	if len(count) == 0 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol4, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(분석데이터:%s, URL:%s)", l.name, lectureCol4, clPageUrl)
 	}
 
 	// 접수상태
-	var status = lectures.ReceptionStatusUnknown
-	switch lectureCol5 {
-	case "바로신청":
-		status = lectures.ReceptionStatusPossible
-	case "접수마감":
-		status = lectures.ReceptionStatusClosed
-	case "대기자 신청":
-		status = lectures.ReceptionStatusStnadBy
-	case "현장문의":
-		status = lectures.ReceptionStatusVisitInquiry
-	case "전화문의":
-		status = lectures.ReceptionStatusTellInquiry
-	case "현장접수":
-		status = lectures.ReceptionStatusVisitInquiry
-	default:
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(지원하지 않는 접수상태입니다(분석데이터:%s, URL:%s)", l.name, lectureCol5, clPageUrl)
-	}
+	status, _ := lookupReceptionStatus(lectureCol5, l.receptionStatusMap, lottemartReceptionStatusMap)
 
 	// 상세페이지
 	classCode, exists := lts.Attr("onclick")
 	if exists == false {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(상세페이지 주소를 찾을 수 없습니다, URL:%s)", l.name, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(상세페이지 주소를 찾을 수 없습니다, URL:%s)", l.name, clPageUrl)
 	}
 	pos1 := strings.Index(classCode, "'")
 	pos2 := strings.LastIndex(classCode, "'")
 	if pos1 == -1 || pos2 == -1 || pos1 == pos2 {
-		log.Fatalf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(상세페이지 주소를 찾을 수 없습니다, URL:%s)", l.name, clPageUrl)
+		return nil, fmt.Errorf("%s 문화센터 강좌 데이터 파싱이 실패하였습니다(상세페이지 주소를 찾을 수 없습니다, URL:%s)", l.name, clPageUrl)
 	}
 	classCode = classCode[pos1+1 : pos2]
 
-	c <- &lectures.Lecture{
+	return &lectures.Lecture{
+		ID:             lectures.NewID(config.ChainLottemart, storeCode, classCode),
+		Chain:          config.ChainLottemart,
 		StoreName:      fmt.Sprintf("%s %s", l.name, storeName),
 		Group:          "",
 		Title:          title,
@@ -257,98 +272,242 @@ func (l *Lottemart) extractCultureLecture(clPageUrl string, storeCode string, st
 		EndTime:        endTime,
 		DayOfTheWeek:   dayOfTheWeek + "요일",
 		Price:          price,
+		Fee:            fee,
+		OriginalFee:    originalFee,
 		Count:          count,
 		Status:         status,
+		StatusText:     lectureCol5,
 		DetailPageUrl:  fmt.Sprintf("%s/cu/gus/course/courseinfo/courseview.do?cls_cd=%s&is_category_open=N&search_term_cd=%s&search_str_cd=%s", l.cultureBaseUrl, classCode, l.searchTermCode, storeCode),
 		ScrapeExcluded: false,
-	}
+	}, nil
 }
 
-func (l *Lottemart) cultureLecturePageDocument(pageNo int, storeCode string) (string, *goquery.Document) {
+func (l *Lottemart) cultureLecturePageDocument(pageNo int, storeCode string) (string, *goquery.Document, error) {
 	clPageUrl := fmt.Sprintf("%s/cu/gus/course/courseinfo/searchList.do", l.cultureBaseUrl)
 
 	paramArrCatCd := ""
 	paramSearchCatCd := ""
-	for _, v := range l.lectureGroupCodeMap {
-		for lectureGroupCode := range v {
-			if paramSearchCatCd != "" {
-				paramSearchCatCd += ","
-			}
-			paramSearchCatCd += lectureGroupCode
+	for lectureGroupCode := range l.lectureGroupCodeMap {
+		if paramSearchCatCd != "" {
+			paramSearchCatCd += ","
+		}
+		paramSearchCatCd += lectureGroupCode
 
-			if paramArrCatCd != "" {
-				paramArrCatCd += "&"
-			}
-			paramArrCatCd += fmt.Sprintf("arr_cat_cd=%s", lectureGroupCode)
+		if paramArrCatCd != "" {
+			paramArrCatCd += "&"
 		}
+		paramArrCatCd += fmt.Sprintf("arr_cat_cd=%s", lectureGroupCode)
 	}
 	reqBody := bytes.NewBufferString(fmt.Sprintf("currPageNo=%d&search_list_type=&search_str_cd=%s&search_order_gbn=&search_reg_status=&is_category_open=Y&from_fg=&cls_cd=&fam_no=&wish_typ=&search_term_cd=%s&search_day_fg=&search_cls_nm=&search_cat_cd=%s&search_opt_cd=&search_tit_cd=&%s", pageNo, storeCode, l.searchTermCode, paramSearchCatCd, paramArrCatCd))
 
 	res, err := http.Post(clPageUrl, "application/x-www-form-urlencoded; charset=UTF-8", reqBody)
-	utils.CheckErr(err)
-	utils.CheckStatusCode(res)
+	if err != nil {
+		return clPageUrl, nil, err
+	}
 
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	if err = utils.StatusCodeError(res); err != nil {
+		return clPageUrl, nil, err
+	}
+
 	resBodyBytes, err := io.ReadAll(res.Body)
-	utils.CheckErr(err)
+	if err != nil {
+		return clPageUrl, nil, err
+	}
 
 	// 실제 불러온 데이터는 '<table>' 태그가 포함되어 있지 않고 '<tr>', '<td>'만 있는 형태!!
 	// 이 형태에서 goquery.NewDocumentFromReader() 함수를 호출하면 '<tr>', '<td>' 태그가 모두 사라지므로 '<table>' 태그를 강제로 붙여준다.
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<table>" + string(resBodyBytes) + "</table>"))
-	utils.CheckErr(err)
+	if err != nil {
+		return clPageUrl, nil, err
+	}
 
-	return clPageUrl, doc
+	return clPageUrl, doc, nil
 }
 
-func (l *Lottemart) validCultureLectureStore(storeCode, storeName string) bool {
+func (l *Lottemart) validCultureLectureStore(storeCode, storeName string) (bool, error) {
+	name, err := l.cultureLectureStoreName(storeCode)
+	if err != nil {
+		return false, err
+	}
+	return name == storeName, nil
+}
+
+// cultureLectureStoreName 점포 메인 페이지에서 점포명을 추출한다.
+func (l *Lottemart) cultureLectureStoreName(storeCode string) (string, error) {
 	res, err := http.Get(fmt.Sprintf("%s/cu/branch/main.do?search_str_cd=%s", l.cultureBaseUrl, storeCode))
-	utils.CheckErr(err)
-	utils.CheckStatusCode(res)
+	if err != nil {
+		return "", err
+	}
 
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	if err = utils.StatusCodeError(res); err != nil {
+		return "", err
+	}
+
 	doc, err := goquery.NewDocumentFromReader(res.Body)
-	utils.CheckErr(err)
+	if err != nil {
+		return "", err
+	}
 
 	vSelection := doc.Find("#contents div.branch_main-wrap div.branch_info-area > div.branch_spot-area > h3")
-	if vSelection.Length() != 1 || utils.CleanString(vSelection.Text()) != storeName {
-		return false
+	if vSelection.Length() != 1 {
+		return "", nil
 	}
 
-	return true
+	return utils.CleanString(vSelection.Text()), nil
 }
 
-func (l *Lottemart) validCultureLectureGroup() bool {
+// CultureLectureGroups 강좌 목록 페이지의 연령별 강좌군 트리를 추출한다.
+func (l *Lottemart) CultureLectureGroups() ([]lectures.Group, error) {
 	res, err := http.Get(fmt.Sprintf("%s/cu/gus/course/courseinfo/courselist.do", l.cultureBaseUrl))
-	utils.CheckErr(err)
-	utils.CheckStatusCode(res)
+	if err != nil {
+		return nil, err
+	}
 
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	if err = utils.StatusCodeError(res); err != nil {
+		return nil, err
+	}
+
 	doc, err := goquery.NewDocumentFromReader(res.Body)
-	utils.CheckErr(err)
+	if err != nil {
+		return nil, err
+	}
 
-	for lectureGroupsID, v := range l.lectureGroupCodeMap {
+	var groups []lectures.Group
+	for _, lectureGroupsID := range []string{"baby-tit", "toddler-tit", "child-tit"} {
 		lectureGroupsIDSelection := doc.Find(fmt.Sprintf("#%s", lectureGroupsID))
 		if lectureGroupsIDSelection.Length() != 1 {
-			return false
+			continue
 		}
 
-		for lectureGroupCode, lectureGroupName := range v {
-			if lectureGroupName == "" {
-				continue
+		category := utils.CleanString(lectureGroupsIDSelection.Text())
+
+		lectureGroupsIDSelection.Parent().Parent().Parent().Find("dd > ul > li > div > input").Each(func(i int, s *goquery.Selection) {
+			lectureGroupCode, exists := s.Attr("value")
+			if exists == false || lectureGroupCode == "" {
+				return
 			}
 
-			lectureGroupSelection := lectureGroupsIDSelection.Parent().Parent().Parent().Find(fmt.Sprintf("dd > ul > li > div > input[value='%s']", lectureGroupCode))
-			if lectureGroupSelection.Length() != 1 || utils.CleanString(lectureGroupSelection.Parent().Text()) != lectureGroupName {
-				return false
+			groups = append(groups, lectures.Group{
+				Code:       lectureGroupCode,
+				Name:       utils.CleanString(s.Parent().Text()),
+				Category:   category,
+				AgeBuckets: []lectures.AgeBucket{lottemartAgeBucketMap[lectureGroupsID]},
+			})
+		})
+	}
+
+	return groups, nil
+}
+
+// Doctor 문화센터 사이트의 구조가 수집기가 기대하는 구조와 일치하는지 점검한다.
+func (l *Lottemart) Doctor() []lectures.Check {
+	var checks []lectures.Check
+
+	// 강좌군
+	groups, err := l.CultureLectureGroups()
+	if err != nil {
+		return append(checks, lectures.NewErrorCheck("강좌군 트리", err))
+	}
+	groupChecks, lectureGroupCodeMap := checkCultureLectureGroups(groups, l.lectureGroupSelectors)
+	checks = append(checks, groupChecks...)
+	l.lectureGroupCodeMap = lectureGroupCodeMap
+
+	// 지원하는 접수상태 버튼 문구
+	var knownStatuses []string
+	for lectureCol5 := range lottemartReceptionStatusMap {
+		knownStatuses = append(knownStatuses, lectureCol5)
+	}
+	for lectureCol5 := range l.receptionStatusMap {
+		knownStatuses = append(knownStatuses, lectureCol5)
+	}
+
+	for storeCode, storeName := range l.storeCodeMap {
+		// 점포
+		if name, err := l.cultureLectureStoreName(storeCode); err != nil {
+			checks = append(checks, lectures.NewErrorCheck(fmt.Sprintf("점포(%s)", storeCode), err))
+		} else {
+			checks = append(checks, lectures.NewCheck(fmt.Sprintf("점포(%s)", storeCode), storeName, name))
+		}
+
+		_, doc, err := l.cultureLecturePageDocument(1, storeCode)
+		if err != nil {
+			checks = append(checks, lectures.NewErrorCheck(fmt.Sprintf("%s 강좌 목록", storeName), err))
+			continue
+		}
+
+		// 페이지 정보
+		pi, _ := doc.Find("tr:last-child").Attr("pageinfo")
+		piValid := len(strings.Split(pi, "|")) == 6
+		for _, v := range strings.Split(pi, "|") {
+			if _, err := strconv.Atoi(v); err != nil {
+				piValid = false
 			}
 		}
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 페이지 정보(tr[pageinfo])", storeName),
+			Expected: "숫자 6개(현재 페이지|전체 페이지|전체 강좌|접수가능|온라인마감|접수마감)",
+			Found:    pi,
+			Passed:   piValid,
+		})
+
+		// 강좌 목록
+		clSelection := doc.Find("tr")
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 강좌 목록(tr)", storeName),
+			Expected: "1개 이상",
+			Found:    fmt.Sprintf("%d개", clSelection.Length()),
+			Passed:   clSelection.Length() > 0,
+		})
+
+		columnMismatchCount := 0
+		unknownStatuses := make(map[string]bool)
+		clSelection.Each(func(i int, s *goquery.Selection) {
+			ls := s.Find("td")
+			if ls.Length() != 5 || ls.Eq(0).Find("div.info-txt > a").Length() == 0 {
+				columnMismatchCount++
+				return
+			}
+
+			lectureCol5 := utils.CleanString(ls.Eq(4).Find("div > div > a.btn-status:last-child").Text())
+			if _, known := lookupReceptionStatus(lectureCol5, l.receptionStatusMap, lottemartReceptionStatusMap); known == false {
+				unknownStatuses[lectureCol5] = true
+			}
+		})
+
+		checks = append(checks, lectures.Check{
+			Name:     fmt.Sprintf("%s 강좌 컬럼(td, div.info-txt > a)", storeName),
+			Expected: "모든 강좌에 5개",
+			Found:    fmt.Sprintf("불일치 %d건", columnMismatchCount),
+			Passed:   columnMismatchCount == 0,
+		})
+		checks = append(checks, checkReceptionStatuses(fmt.Sprintf("%s 접수상태(a.btn-status)", storeName), knownStatuses, unknownStatuses))
+	}
+
+	return checks
+}
+
+// EnrichCultureLecture 강좌 상세페이지를 수집하여 강좌의 커리큘럼, 수강대상, 준비물, 강의실, 강의일자를 채운다.
+func (l *Lottemart) EnrichCultureLecture(lecture *lectures.Lecture, cache *DetailPageCache) error {
+	data, err := cache.Get(lecture.DetailPageUrl)
+	if err != nil {
+		return err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return err
 	}
 
-	return true
+	enrichLectureFromDetailDocument(lecture, doc.Selection)
+
+	return nil
 }
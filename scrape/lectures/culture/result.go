@@ -0,0 +1,40 @@
+package culture
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"sync"
+)
+
+// lectureResult 강좌 데이터 추출 결과
+type lectureResult struct {
+	lecture *lectures.Lecture
+	err     error
+}
+
+// firstError 여러 고루틴에서 발생한 오류 중에서 처음 발생한 오류를 보관한다.
+type firstError struct {
+	mu  sync.Mutex
+	err error
+}
+
+// Set 처음 발생한 오류를 보관한다. 이미 보관된 오류가 있거나 err이 nil이면 무시한다.
+func (e *firstError) Set(err error) {
+	if err == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// Err 보관된 오류를 반환한다.
+func (e *firstError) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.err
+}
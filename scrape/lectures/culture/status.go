@@ -0,0 +1,17 @@
+package culture
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+)
+
+// lookupReceptionStatus 접수상태 문구에 해당하는 접수상태를 반환한다.
+// 설정 파일에 지정된 접수상태 문구가 수집기의 기본 접수상태보다 우선하며, 어디에도 없는 문구는 알수없음으로 처리한다.
+func lookupReceptionStatus(text string, configured, builtin map[string]lectures.ReceptionStatus) (lectures.ReceptionStatus, bool) {
+	if status, exists := configured[text]; exists == true {
+		return status, true
+	}
+	if status, exists := builtin[text]; exists == true {
+		return status, true
+	}
+	return lectures.ReceptionStatusUnknown, false
+}
@@ -0,0 +1,36 @@
+package culture
+
+import (
+	"log"
+	"strings"
+)
+
+// selectCultureLectureStores 점포 목록에서 설정된 점포(점포코드 또는 점포명)만 선택한다.
+// 설정된 점포가 없으면 전체 점포를 선택하며, 선택한 점포가 하나라도 존재하지 않으면 수집을 중단한다.
+func selectCultureLectureStores(name string, storeCodeMap map[string]string, selectors []string) map[string]string {
+	if len(selectors) == 0 {
+		return storeCodeMap
+	}
+
+	var unmatched []string
+
+	selectedStoreCodeMap := make(map[string]string)
+	for _, selector := range selectors {
+		matched := false
+		for storeCode, storeName := range storeCodeMap {
+			if selector == storeCode || selector == storeName {
+				selectedStoreCodeMap[storeCode] = storeName
+				matched = true
+			}
+		}
+		if matched == false {
+			unmatched = append(unmatched, selector)
+		}
+	}
+
+	if len(unmatched) > 0 {
+		log.Fatalf("%s 문화센터에서 지원하지 않는 점포가 설정되어 있습니다(점포:%s)", name, strings.Join(unmatched, ", "))
+	}
+
+	return selectedStoreCodeMap
+}
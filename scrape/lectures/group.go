@@ -0,0 +1,62 @@
+package lectures
+
+// AgeBucket 체인 공통 연령대
+type AgeBucket string
+
+// 지원가능한 연령대 값
+const (
+	AgeBucketBaby    AgeBucket = "baby"    // 영아
+	AgeBucketToddler AgeBucket = "toddler" // 유아
+	AgeBucketChild   AgeBucket = "child"   // 어린이
+	AgeBucketAdult   AgeBucket = "adult"   // 성인
+)
+
+// AgeBuckets 지원가능한 연령대 목록
+var AgeBuckets = []AgeBucket{AgeBucketBaby, AgeBucketToddler, AgeBucketChild, AgeBucketAdult}
+
+// Group 강좌군
+type Group struct {
+	Code       string      // 강좌군 코드
+	Name       string      // 강좌군명
+	Category   string      // 상위 분류명
+	AgeBuckets []AgeBucket // 강좌군이 포함하는 연령대
+}
+
+// HasAgeBucket 강좌군이 주어진 연령대를 포함하는지의 여부를 반환한다.
+func (g *Group) HasAgeBucket(bucket AgeBucket) bool {
+	for _, v := range g.AgeBuckets {
+		if v == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectGroups 강좌군명 또는 연령대로 이루어진 선택자를 이용하여 강좌군 목록에서 수집할 강좌군을 선택한다.
+// 어떤 강좌군과도 일치하지 않은 선택자는 두번째 반환값으로 반환된다.
+func SelectGroups(groups []Group, selectors []string) ([]Group, []string) {
+	var selected []Group
+	var unmatched []string
+
+	exists := make(map[string]bool)
+	for _, selector := range selectors {
+		matched := false
+		for _, g := range groups {
+			if g.Name != selector && g.HasAgeBucket(AgeBucket(selector)) == false {
+				continue
+			}
+
+			matched = true
+			if exists[g.Code] == false {
+				exists[g.Code] = true
+				selected = append(selected, g)
+			}
+		}
+
+		if matched == false {
+			unmatched = append(unmatched, selector)
+		}
+	}
+
+	return selected, unmatched
+}
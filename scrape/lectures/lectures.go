@@ -1,38 +1,139 @@
 package lectures
 
-type Lecture struct {
-	StoreName      string          // 점포
-	Group          string          // 강좌그룹
-	Title          string          // 강좌명
-	Teacher        string          // 강사명
-	StartDate      string          // 개강일(YYYY-MM-DD)
-	StartTime      string          // 시작시간(hh:mm) : 24시간 형식
-	EndTime        string          // 종료시간(hh:mm) : 24시간 형식
-	DayOfTheWeek   string          // 요일
-	Price          string          // 수강료
-	Count          string          // 강좌횟수
-	Status         ReceptionStatus // 접수상태
-	DetailPageUrl  string          // 상세페이지
-	ScrapeExcluded bool            // 필터링에 걸려서 파일 저장시 제외되는지의 여부(csv 파일에 포함되지 않는다)
-}
-
-// ReceptionStatus 접수상태
-type ReceptionStatus uint
-
-// 지원가능한 접수상태 값
-const (
-	ReceptionStatusUnknown                   ReceptionStatus = iota // 알수없음
-	ReceptionStatusPlanned                                          // 접수예정
-	ReceptionStatusPossible                                         // 접수가능
-	ReceptionStatusClosed                                           // 접수마감
-	ReceptionStatusStnadBy                                          // 대기신청
-	ReceptionStatusVisitConsultation                                // 방문상담
-	ReceptionStatusVisitFirstComeFirstServed                        // 방문선착순
-	ReceptionStatusVisitInquiry                                     // 현장문의
-	ReceptionStatusTellInquiry                                      // 전화문의
-	ReceptionStatusDayParticipation                                 // 당일참여
-	ReceptionStatusMax
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
 )
 
-// ReceptionStatusString 지원가능한 접수상태 문자열
-var ReceptionStatusString = [ReceptionStatusMax]string{"알수없음", "접수예정", "접수가능", "접수마감", "대기신청", "방문상담", "방문선착순", "현장문의", "전화문의", "당일참여"}
+// DateTimeLayout 강좌의 일시 항목(접수시작일시 등) 형식
+const DateTimeLayout = "2006-01-02 15:04"
+
+type Lecture struct {
+	ID              string            `json:"id"`              // 강좌 ID(체인 ID/점포코드/문화센터 사이트의 강좌 ID)
+	Chain           string            `json:"chain"`           // 문화센터 체인 ID
+	StoreName       string            `json:"storeName"`       // 점포
+	StoreAddress    string            `json:"storeAddress"`    // 점포 주소(문화센터 사이트에서 제공하거나 설정 파일에 지정된 경우)
+	StorePhone      string            `json:"storePhone"`      // 점포 전화번호
+	Group           string            `json:"group"`           // 강좌그룹
+	GroupCode       string            `json:"groupCode"`       // 강좌그룹 코드(문화센터 사이트에서 제공하는 경우)
+	Category        Category          `json:"category"`        // 체인 공통 활동 분류
+	Title           string            `json:"title"`           // 강좌명
+	Program         string            `json:"program"`         // 프로그램 키(정규화된 강좌명, 여러 점포 및 체인에서 같은 프로그램이면 같은 값)
+	Teacher         string            `json:"teacher"`         // 강사명
+	StartDate       string            `json:"startDate"`       // 개강일(YYYY-MM-DD)
+	EndDate         string            `json:"endDate"`         // 종강일(YYYY-MM-DD, 알 수 없으면 빈 문자열)
+	StartTime       string            `json:"startTime"`       // 시작시간(hh:mm) : 24시간 형식
+	EndTime         string            `json:"endTime"`         // 종료시간(hh:mm) : 24시간 형식
+	DayOfTheWeek    string            `json:"dayOfTheWeek"`    // 요일
+	Price           string            `json:"price"`           // 수강료
+	Fee             int               `json:"fee"`             // 수강료(할인된 금액, 원)
+	OriginalFee     int               `json:"originalFee"`     // 정가(할인되지 않은 금액, 원)
+	Discount        int               `json:"discount"`        // 할인금액(원)
+	MaterialFee     int               `json:"materialFee"`     // 재료비(수강료와 따로 받는 경우, 원)
+	Persons         int               `json:"persons"`         // 수강료의 기준 인원(예:2인 기준이면 2)
+	SessionFee      int               `json:"sessionFee"`      // 재료비를 포함한 1인 1회당 수강료(원, 알 수 없으면 0)
+	Count           string            `json:"count"`           // 강좌횟수
+	Status          ReceptionStatus   `json:"status"`          // 접수상태
+	StatusText      string            `json:"statusText"`      // 접수상태 원문(문화센터 사이트에 표시된 문자열)
+	DetailPageUrl   string            `json:"detailPageUrl"`   // 상세페이지
+	Curriculum      string            `json:"curriculum"`      // 커리큘럼(상세페이지)
+	TargetAge       string            `json:"targetAge"`       // 수강대상(상세페이지)
+	Materials       string            `json:"materials"`       // 준비물(상세페이지)
+	Classroom       string            `json:"classroom"`       // 강의실(상세페이지)
+	SessionDates    []string          `json:"sessionDates"`    // 강의일자 목록(YYYY-MM-DD, 상세페이지에 없으면 개강일부터 매주 강좌횟수만큼 계산된다)
+	RegisterStart   string            `json:"registerStart"`   // 접수시작일시(YYYY-MM-DD hh:mm, 이마트)
+	RegisterEnd     string            `json:"registerEnd"`     // 접수종료일시(YYYY-MM-DD hh:mm, 이마트)
+	CancelStart     string            `json:"cancelStart"`     // 취소시작일시(YYYY-MM-DD hh:mm, 이마트)
+	CancelEnd       string            `json:"cancelEnd"`       // 취소종료일시(YYYY-MM-DD hh:mm, 이마트)
+	Distance        float64           `json:"distance"`        // 집에서 점포까지의 추정 도로거리(km, 알 수 없으면 0)
+	TravelMinutes   int               `json:"travelMinutes"`   // 집에서 점포까지의 추정 이동시간(분)
+	DistanceApprox  bool              `json:"distanceApprox"`  // 점포 또는 집의 위치를 시, 군 위치로 추정하여 거리가 부정확한지의 여부
+	Learners        []string          `json:"learners"`        // 필터링 결과 수강 가능한 수강자 목록
+	ExcludedBy      string            `json:"excludedBy"`      // 강좌를 제외한 필터링 규칙 이름(제외되지 않았으면 빈 문자열)
+	ExcludedReasons []ExclusionReason `json:"excludedReasons"` // 강좌가 필터링 규칙을 만족하지 않은 사유 목록(수강자별로 필터링하면 수강할 수 없는 수강자의 사유가 포함된다)
+	ScrapeExcluded  bool              `json:"scrapeExcluded"`  // 필터링에 걸려서 파일 저장시 제외되는지의 여부(csv 파일에 포함되지 않는다)
+}
+
+// ExclusionReason 강좌가 필터링 규칙을 만족하지 않은 사유
+type ExclusionReason struct {
+	Rule    string `json:"rule"`              // 필터링 규칙 이름
+	Detail  string `json:"detail"`            // 상세 사유(예:연령 5~7세, 수강자 9세)
+	Learner string `json:"learner,omitempty"` // 수강자 이름(수강자별로 필터링한 경우)
+}
+
+func (r ExclusionReason) String() string {
+	s := r.Rule
+	if r.Detail != "" {
+		s = fmt.Sprintf("%s: %s", r.Rule, r.Detail)
+	}
+	if r.Learner != "" {
+		s = fmt.Sprintf("[%s] %s", r.Learner, s)
+	}
+	return s
+}
+
+// NewID 체인 ID, 점포코드, 문화센터 사이트의 강좌 ID로 수집 실행과 관계없이 강좌를 구분할 수 있는 강좌 ID를 생성한다.
+func NewID(chain, storeCode, nativeID string) string {
+	return fmt.Sprintf("%s/%s/%s", chain, storeCode, nativeID)
+}
+
+// Key 여러 수집 결과에서 같은 강좌를 구분하는 키를 반환한다.
+// 강좌 ID가 없는 이전 수집 결과의 강좌는 체인 ID/점포/상세페이지 주소를 키로 사용한다.
+func Key(lecture *Lecture) string {
+	if lecture.ID != "" {
+		return lecture.ID
+	}
+	return fmt.Sprintf("%s/%s/%s", lecture.Chain, lecture.StoreName, lecture.DetailPageUrl)
+}
+
+// RegisterStartTime 접수시작일시를 반환한다. 접수시작일시가 없거나 올바르지 않으면 false를 반환한다.
+func (l *Lecture) RegisterStartTime() (time.Time, bool) {
+	return parseDateTime(l.RegisterStart)
+}
+
+// RegisterEndTime 접수종료일시를 반환한다. 접수종료일시가 없거나 올바르지 않으면 false를 반환한다.
+func (l *Lecture) RegisterEndTime() (time.Time, bool) {
+	return parseDateTime(l.RegisterEnd)
+}
+
+// StartDateTime 개강일을 반환한다. 개강일이 없거나 올바르지 않으면 false를 반환한다.
+func (l *Lecture) StartDateTime() (time.Time, bool) {
+	t, err := time.ParseInLocation("2006-01-02", l.StartDate, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// EndDateTime 종강일을 반환한다. 종강일이 없거나 올바르지 않으면 false를 반환한다.
+func (l *Lecture) EndDateTime() (time.Time, bool) {
+	t, err := time.ParseInLocation("2006-01-02", l.EndDate, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// SessionCount 강좌횟수를 반환한다. 강좌횟수를 알 수 없으면 강의일자 갯수를 반환한다.
+func (l *Lecture) SessionCount() int {
+	if v := regexp.MustCompile("[0-9]+").FindString(l.Count); v != "" {
+		if count, err := strconv.Atoi(v); err == nil && count > 0 {
+			return count
+		}
+	}
+	return len(l.SessionDates)
+}
+
+func parseDateTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	t, err := time.ParseInLocation(DateTimeLayout, s, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
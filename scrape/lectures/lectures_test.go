@@ -0,0 +1,20 @@
+package lectures
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		lecture Lecture
+		want    string
+	}{
+		{Lecture{ID: NewID("emart", "560", "12345"), Chain: "emart", StoreName: "이마트 여수점"}, "emart/560/12345"},
+		// 강좌 ID가 없는 이전 수집 결과의 강좌
+		{Lecture{Chain: "lottemart", StoreName: "롯데마트 여수점", DetailPageUrl: "http://example.com/1"}, "lottemart/롯데마트 여수점/http://example.com/1"},
+	}
+
+	for _, tt := range tests {
+		if got := Key(&tt.lecture); got != tt.want {
+			t.Errorf("Key() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package lectures
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// 수강료 금액(예:60,000원, 60000원)
+	priceAmountRegexp = regexp.MustCompile(`([0-9]{1,3}(?:,[0-9]{3})+|[0-9]+)\s*원`)
+
+	// 단위 없이 숫자만으로 된 수강료(예:60000, 60,000)
+	priceNumberRegexp = regexp.MustCompile(`^\s*([0-9]{1,3}(?:,[0-9]{3})+|[0-9]+)\s*$`)
+
+	// 수강료의 기준 인원(예:(2인 기준))
+	pricePersonsRegexp = regexp.MustCompile(`\(\s*([0-9]+)\s*인\s*기준\s*\)`)
+)
+
+// ParsePrice 수강료 원문에서 '원'으로 끝나는 금액 목록을 찾아 수강료(마지막 금액)와 정가(처음 금액)를 반환한다.
+// 롯데마트처럼 정가와 할인된 수강료가 함께 표시되는 경우(예:12회 80,000원 60,000원)를 처리하며, 강좌횟수, 기간(예:3개월), 날짜 등 '원'으로 끝나지 않는 숫자는 금액으로 보지 않는다.
+// 이마트 및 CSV 파일처럼 원문이 숫자만으로 되어 있으면 그 숫자를 금액으로 하며, 금액이 없으면 false를 반환한다.
+func ParsePrice(s string) (fee int, originalFee int, ok bool) {
+	matches := priceAmountRegexp.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		matches = priceNumberRegexp.FindAllStringSubmatch(s, -1)
+	}
+
+	var amounts []int
+	for _, m := range matches {
+		amount, err := strconv.Atoi(strings.ReplaceAll(m[1], ",", ""))
+		if err != nil {
+			continue
+		}
+		amounts = append(amounts, amount)
+	}
+	if len(amounts) == 0 {
+		return 0, 0, false
+	}
+
+	fee, originalFee = amounts[len(amounts)-1], amounts[0]
+	if originalFee < fee {
+		originalFee = fee
+	}
+	return fee, originalFee, true
+}
+
+// ParsePricePersons 수강료 원문에서 수강료의 기준 인원(예:(2인 기준))을 반환한다. 기준 인원이 없으면 1을 반환한다.
+func ParsePricePersons(s string) int {
+	if m := pricePersonsRegexp.FindStringSubmatch(s); m != nil {
+		if persons, err := strconv.Atoi(m[1]); err == nil && persons > 0 {
+			return persons
+		}
+	}
+	return 1
+}
+
+// NormalizePrice 수강료 원문에서 수강료, 정가를 추출하고 할인금액과 1인 1회당 수강료를 계산한다.
+// 문화센터 사이트에서 수강료를 금액으로 제공하여 수집할 때 이미 지정된 경우(이마트)에는 지정된 금액을 사용한다.
+// 강의일자 목록에 따라 강좌횟수가 달라질 수 있으므로, 강의일자 목록을 계산한 후에 다시 호출할 수 있다.
+func (l *Lecture) NormalizePrice() {
+	if l.Fee == 0 && l.OriginalFee == 0 {
+		if fee, originalFee, ok := ParsePrice(l.Price); ok == true {
+			l.Fee, l.OriginalFee = fee, originalFee
+		}
+	}
+	if l.OriginalFee < l.Fee {
+		l.OriginalFee = l.Fee
+	}
+	if l.Persons <= 0 {
+		l.Persons = 1
+	}
+
+	l.Discount = l.OriginalFee - l.Fee
+
+	l.SessionFee = 0
+	if count := l.SessionCount(); count > 0 && (l.Fee > 0 || l.MaterialFee > 0) {
+		l.SessionFee = (l.Fee + l.MaterialFee) / l.Persons / count
+	}
+}
+
+// PriceValue 수강료(할인된 금액)를 숫자로 반환한다. 수강료를 알 수 없으면 false를 반환한다.
+func (l *Lecture) PriceValue() (int, bool) {
+	if l.Fee > 0 || l.OriginalFee > 0 {
+		return l.Fee, true
+	}
+
+	fee, _, ok := ParsePrice(l.Price)
+	return fee, ok
+}
+
+// SessionFeeValue 재료비를 포함한 1인 1회당 수강료를 반환한다. 수강료 또는 강좌횟수를 알 수 없으면 false를 반환한다.
+func (l *Lecture) SessionFeeValue() (int, bool) {
+	if l.SessionFee > 0 {
+		return l.SessionFee, true
+	}
+
+	fee, ok := l.PriceValue()
+	count := l.SessionCount()
+	if ok == false || count <= 0 {
+		return 0, false
+	}
+
+	persons := l.Persons
+	if persons <= 0 {
+		persons = 1
+	}
+	return (fee + l.MaterialFee) / persons / count, true
+}
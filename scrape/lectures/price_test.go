@@ -0,0 +1,91 @@
+package lectures
+
+import (
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		s                string
+		fee, originalFee int
+		ok               bool
+	}{
+		{"6,000원", 6000, 6000, true},
+		{"80,000원 60,000원", 60000, 80000, true},           // 롯데마트 : 정가, 할인된 수강료
+		{"12회 80,000원 60,000원", 60000, 80000, true},       // 롯데마트 : 강좌횟수, 정가, 할인된 수강료
+		{"1회 7,000원 (2인 기준)", 7000, 7000, true},           // 홈플러스
+		{"3개월 90000원", 90000, 90000, true},                // 기간은 금액이 아니다
+		{"2025.03.04 개강 12회 60,000원", 60000, 60000, true}, // 날짜는 금액이 아니다
+		{"60,000원 (3개월)", 60000, 60000, true},
+		{"60,000원 (2025.03.04~2025.05.27)", 60000, 60000, true},
+		{"60000", 60000, 60000, true}, // 이마트, CSV 파일 : 숫자만 있는 경우
+		{"60,000", 60000, 60000, true},
+		{"12회", 0, 0, false},
+		{"무료", 0, 0, false},
+		{"", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		fee, originalFee, ok := ParsePrice(tt.s)
+		if fee != tt.fee || originalFee != tt.originalFee || ok != tt.ok {
+			t.Errorf("ParsePrice(%q) = (%d, %d, %v), want (%d, %d, %v)", tt.s, fee, originalFee, ok, tt.fee, tt.originalFee, tt.ok)
+		}
+	}
+}
+
+func TestParsePricePersons(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"1회 7,000원 (2인 기준)", 2},
+		{"1회 10,000원 ( 3 인 기준 )", 3},
+		{"1회 7,000원", 1},
+		{"(0인 기준)", 1},
+	}
+
+	for _, tt := range tests {
+		if got := ParsePricePersons(tt.s); got != tt.want {
+			t.Errorf("ParsePricePersons(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		lecture Lecture
+		want    [4]int // 수강료, 정가, 할인금액, 1인 1회당 수강료
+	}{
+		{
+			// 이마트 : 수강료, 정가, 재료비를 금액으로 수집한다.
+			name:    "이마트",
+			lecture: Lecture{Price: "60000", Fee: 60000, OriginalFee: 70000, MaterialFee: 12000, Count: "12회"},
+			want:    [4]int{60000, 70000, 10000, 6000},
+		},
+		{
+			name:    "롯데마트",
+			lecture: Lecture{Price: "80,000원 60,000원", Count: "12회"},
+			want:    [4]int{60000, 80000, 20000, 5000},
+		},
+		{
+			// 홈플러스 : 기준 인원으로 나눈 1인당 수강료를 계산한다.
+			name:    "홈플러스",
+			lecture: Lecture{Price: "1회 7,000원", Persons: ParsePricePersons("1회 7,000원 (2인 기준)"), Count: "1회"},
+			want:    [4]int{7000, 7000, 0, 3500},
+		},
+		{
+			name:    "강좌횟수를 모르는 경우",
+			lecture: Lecture{Price: "6,000원"},
+			want:    [4]int{6000, 6000, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		l := tt.lecture
+		l.NormalizePrice()
+		if got := [4]int{l.Fee, l.OriginalFee, l.Discount, l.SessionFee}; got != tt.want {
+			t.Errorf("%s: NormalizePrice() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package lectures
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReceptionStatus 접수상태
+type ReceptionStatus uint
+
+// 지원가능한 접수상태 값
+const (
+	ReceptionStatusUnknown                   ReceptionStatus = iota // 알수없음
+	ReceptionStatusPlanned                                          // 접수예정
+	ReceptionStatusPossible                                         // 접수가능
+	ReceptionStatusClosed                                           // 접수마감
+	ReceptionStatusStandBy                                          // 대기신청
+	ReceptionStatusVisitConsultation                                // 방문상담
+	ReceptionStatusVisitFirstComeFirstServed                        // 방문선착순
+	ReceptionStatusVisitInquiry                                     // 현장문의
+	ReceptionStatusTellInquiry                                      // 전화문의
+	ReceptionStatusDayParticipation                                 // 당일참여
+	ReceptionStatusOnlineClosed                                     // 온라인마감(현장접수는 가능)
+	ReceptionStatusMax
+)
+
+// ReceptionStatusString 지원가능한 접수상태 문자열
+var ReceptionStatusString = [ReceptionStatusMax]string{"알수없음", "접수예정", "접수가능", "접수마감", "대기신청", "방문상담", "방문선착순", "현장문의", "전화문의", "당일참여", "온라인마감"}
+
+// receptionStatusAliases 문화센터 사이트에서 사용되는 접수상태 문구 중에서 접수상태 문자열과 다른 문구
+var receptionStatusAliases = map[string]ReceptionStatus{
+	"접수중":   ReceptionStatusPossible,
+	"바로신청":  ReceptionStatusPossible,
+	"마감":    ReceptionStatusClosed,
+	"정원마감":  ReceptionStatusClosed,
+	"대기":    ReceptionStatusStandBy,
+	"접수대기":  ReceptionStatusStandBy,
+	"대기자신청": ReceptionStatusStandBy,
+	"방문":    ReceptionStatusVisitConsultation,
+	"현장접수":  ReceptionStatusVisitInquiry,
+}
+
+// ReceptionCategory 접수상태 분류
+type ReceptionCategory uint
+
+// 지원가능한 접수상태 분류 값
+const (
+	ReceptionCategoryUnknown     ReceptionCategory = iota // 알수없음
+	ReceptionCategoryPlanned                              // 접수예정
+	ReceptionCategoryOnline                               // 온라인 접수가능
+	ReceptionCategoryWaitlist                             // 대기신청
+	ReceptionCategoryOfflineOnly                          // 현장(방문, 전화) 접수만 가능
+	ReceptionCategoryClosed                               // 접수마감
+	ReceptionCategoryMax
+)
+
+// ReceptionCategoryString 지원가능한 접수상태 분류 문자열
+var ReceptionCategoryString = [ReceptionCategoryMax]string{"알수없음", "접수예정", "온라인접수", "대기신청", "현장접수", "접수마감"}
+
+func (c ReceptionCategory) String() string {
+	if c >= ReceptionCategoryMax {
+		return fmt.Sprintf("ReceptionCategory(%d)", uint(c))
+	}
+	return ReceptionCategoryString[c]
+}
+
+// ParseReceptionStatus 접수상태 문자열(예:접수마감) 또는 문화센터 사이트의 접수상태 문구(예:접수중)에 해당하는 접수상태를 반환한다.
+func ParseReceptionStatus(s string) (ReceptionStatus, bool) {
+	s = strings.TrimSpace(s)
+
+	for i, v := range ReceptionStatusString {
+		if v == s {
+			return ReceptionStatus(i), true
+		}
+	}
+
+	if status, exists := receptionStatusAliases[strings.ReplaceAll(s, " ", "")]; exists == true {
+		return status, true
+	}
+
+	return ReceptionStatusUnknown, false
+}
+
+func (s ReceptionStatus) String() string {
+	if s >= ReceptionStatusMax {
+		return fmt.Sprintf("ReceptionStatus(%d)", uint(s))
+	}
+	return ReceptionStatusString[s]
+}
+
+// Category 접수상태의 분류를 반환한다.
+func (s ReceptionStatus) Category() ReceptionCategory {
+	switch s {
+	case ReceptionStatusPlanned:
+		return ReceptionCategoryPlanned
+	case ReceptionStatusPossible:
+		return ReceptionCategoryOnline
+	case ReceptionStatusStandBy:
+		return ReceptionCategoryWaitlist
+	case ReceptionStatusVisitConsultation, ReceptionStatusVisitFirstComeFirstServed, ReceptionStatusVisitInquiry, ReceptionStatusTellInquiry, ReceptionStatusDayParticipation, ReceptionStatusOnlineClosed:
+		return ReceptionCategoryOfflineOnly
+	case ReceptionStatusClosed:
+		return ReceptionCategoryClosed
+	default:
+		return ReceptionCategoryUnknown
+	}
+}
+
+// CanRegisterOnline 온라인으로 수강신청이 가능한지의 여부를 반환한다.
+func (s ReceptionStatus) CanRegisterOnline() bool {
+	return s.Category() == ReceptionCategoryOnline
+}
+
+// MarshalText 접수상태를 접수상태 문자열로 변환한다.
+func (s ReceptionStatus) MarshalText() ([]byte, error) {
+	if s >= ReceptionStatusMax {
+		return nil, fmt.Errorf("지원하지 않는 접수상태입니다(접수상태:%d)", uint(s))
+	}
+	return []byte(ReceptionStatusString[s]), nil
+}
+
+// UnmarshalText 접수상태 문자열을 접수상태로 변환한다.
+func (s *ReceptionStatus) UnmarshalText(text []byte) error {
+	status, ok := ParseReceptionStatus(string(text))
+	if ok == false {
+		return fmt.Errorf("지원하지 않는 접수상태입니다(접수상태:%s)", string(text))
+	}
+	*s = status
+	return nil
+}
+
+// MarshalJSON 접수상태를 JSON 문자열로 변환한다.
+func (s ReceptionStatus) MarshalJSON() ([]byte, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
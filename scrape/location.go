@@ -0,0 +1,108 @@
+package scrape
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/geo"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StoreLocation 강좌가 진행되는 점포의 위치를 반환한다.
+// 설정 파일에 지정된 점포 좌표, 점포 주소의 시, 군 위치, 점포명의 시, 군 위치 순서로 찾으며, 시, 군 위치로 추정하면 approx가 true이다.
+func StoreLocation(gc config.GeoConfig, lecture *lectures.Lecture) (p geo.Point, approx bool, ok bool) {
+	lc := gc.Stores[lecture.StoreName]
+	if lc.Address == "" {
+		lc.Address = lecture.StoreAddress
+	}
+	if p, approx, ok = lc.Locate(); ok == true {
+		return p, approx, true
+	}
+
+	if p, _, ok = geo.LookupCity(lecture.StoreName); ok == true {
+		return p, true, true
+	}
+	return geo.Point{}, false, false
+}
+
+// Locate 강좌의 점포 주소 및 전화번호를 채우고, 집 위치가 설정되어 있으면 집에서 점포까지의 거리와 이동시간을 계산한다.
+// 거리는 직선거리에 도로 우회 비율을 곱한 추정값이며, 점포 위치를 알 수 없는 강좌는 거리가 0이다.
+func (s *Scrape) Locate() {
+	for i := range s.lectures {
+		Locate(s.config.Geo, &s.lectures[i])
+	}
+
+	// 점포 위치를 알 수 없는 점포는 거리 필터링 규칙으로 걸러지지 않을 수 있으므로 알려준다.
+	if _, _, ok := s.config.Geo.HomeLocation(); ok == false {
+		return
+	}
+	var unlocated []string
+	for i := range s.lectures {
+		if _, _, ok := StoreLocation(s.config.Geo, &s.lectures[i]); ok == false && utils.Contains(unlocated, s.lectures[i].StoreName) == false {
+			unlocated = append(unlocated, s.lectures[i].StoreName)
+		}
+	}
+	if len(unlocated) > 0 {
+		sort.Strings(unlocated)
+		log.Printf("위치를 알 수 없어 거리를 계산하지 못한 점포가 %d개 있습니다(%s). 설정 파일의 'geo.stores' 항목에 점포 좌표 또는 주소를 지정하세요.", len(unlocated), strings.Join(unlocated, ", "))
+	}
+}
+
+// Locate 강좌 하나의 점포 주소, 전화번호, 집에서 점포까지의 거리 및 이동시간을 채운다.
+func Locate(gc config.GeoConfig, lecture *lectures.Lecture) {
+	if lc, exists := gc.Stores[lecture.StoreName]; exists == true {
+		if lc.Address != "" {
+			lecture.StoreAddress = lc.Address
+		}
+		if lc.Phone != "" {
+			lecture.StorePhone = lc.Phone
+		}
+	}
+
+	lecture.Distance, lecture.TravelMinutes, lecture.DistanceApprox = 0, 0, false
+
+	home, homeApprox, ok := gc.HomeLocation()
+	if ok == false {
+		return
+	}
+	p, approx, ok := StoreLocation(gc, lecture)
+	if ok == false {
+		return
+	}
+
+	distance, travelTime := geo.Estimate(geo.Distance(home, p), gc.DetourFactor, gc.Speed)
+	lecture.Distance = math.Round(distance*10) / 10
+	lecture.TravelMinutes = int(math.Ceil(travelTime.Minutes()))
+	lecture.DistanceApprox = homeApprox == true || approx == true
+}
+
+// formatDistance 거리를 CSV 파일에 저장할 문자열(예:12.3)로 변환한다(알 수 없으면 빈 문자열).
+func formatDistance(distance float64) string {
+	if distance <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(distance, 'f', 1, 64)
+}
+
+// formatMinutes 이동시간을 CSV 파일에 저장할 문자열로 변환한다(알 수 없으면 빈 문자열).
+func formatMinutes(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	return strconv.Itoa(minutes)
+}
+
+// SortByDistance 강좌를 집에서 가까운 점포 순서로 정렬한다. 거리를 알 수 없는 강좌는 마지막에 위치하며, 같은 점포의 강좌는 원래 순서를 유지한다.
+func (s *Scrape) SortByDistance() {
+	sort.SliceStable(s.lectures, func(i, j int) bool {
+		a, b := s.lectures[i].Distance, s.lectures[j].Distance
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+}
@@ -0,0 +1,164 @@
+package scrape
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"os"
+	"sort"
+)
+
+// 수강료 요약 구분
+const (
+	PriceByChain    = "chain"    // 체인별
+	PriceByStore    = "store"    // 점포별
+	PriceByCategory = "category" // 활동 분류별
+)
+
+// PriceSummaryKinds 지원가능한 수강료 요약 구분 목록
+var PriceSummaryKinds = []string{PriceByChain, PriceByStore, PriceByCategory}
+
+// PriceSummary 구분(체인, 점포, 활동 분류)별 수강료 요약
+type PriceSummary struct {
+	Kind              string `json:"kind"`              // 구분(chain, store, category)
+	Name              string `json:"name"`              // 구분 값(예:emart, 이마트 죽전점, 음악)
+	LectureCount      int    `json:"lectureCount"`      // 1인 1회당 수강료를 알 수 있는 강좌 갯수
+	DiscountedCount   int    `json:"discountedCount"`   // 할인된 강좌 갯수
+	AverageFee        int    `json:"averageFee"`        // 평균 수강료
+	AverageSessionFee int    `json:"averageSessionFee"` // 평균 1인 1회당 수강료
+	MinSessionFee     int    `json:"minSessionFee"`     // 최저 1인 1회당 수강료
+	MaxSessionFee     int    `json:"maxSessionFee"`     // 최고 1인 1회당 수강료
+}
+
+// NormalizePrices 모든 강좌의 수강료, 정가, 할인금액 및 1인 1회당 수강료를 계산한다.
+func (s *Scrape) NormalizePrices() {
+	for i := range s.lectures {
+		s.lectures[i].NormalizePrice()
+	}
+}
+
+// PriceSummaries 구분(PriceByChain, PriceByStore, PriceByCategory)별로 강좌의 1인 1회당 수강료를 요약한다.
+// 필터링 여부와 관계없이 수강료를 알 수 있는 모든 강좌를 대상으로 하며, 평균 1인 1회당 수강료가 높은 순서로 정렬된다.
+func (s *Scrape) PriceSummaries(kind string) []PriceSummary {
+	type total struct {
+		count, discounted, fee, sessionFee, min, max int
+	}
+
+	totals := make(map[string]*total)
+	for i := range s.lectures {
+		lecture := &s.lectures[i]
+
+		sessionFee, ok := lecture.SessionFeeValue()
+		if ok == false {
+			continue
+		}
+		fee, _ := lecture.PriceValue()
+
+		var name string
+		switch kind {
+		case PriceByChain:
+			name = lecture.Chain
+		case PriceByStore:
+			name = lecture.StoreName
+		case PriceByCategory:
+			name = lecture.Category.Name()
+		default:
+			log.Fatalf("지원하지 않는 수강료 요약 구분입니다(%s)", kind)
+		}
+
+		t, exists := totals[name]
+		if exists == false {
+			t = &total{min: sessionFee, max: sessionFee}
+			totals[name] = t
+		}
+		t.count++
+		t.fee += fee
+		t.sessionFee += sessionFee
+		if lecture.OriginalFee > fee {
+			t.discounted++
+		}
+		t.min = min(t.min, sessionFee)
+		t.max = max(t.max, sessionFee)
+	}
+
+	summaries := make([]PriceSummary, 0, len(totals))
+	for name, t := range totals {
+		summaries = append(summaries, PriceSummary{
+			Kind:              kind,
+			Name:              name,
+			LectureCount:      t.count,
+			DiscountedCount:   t.discounted,
+			AverageFee:        t.fee / t.count,
+			AverageSessionFee: t.sessionFee / t.count,
+			MinSessionFee:     t.min,
+			MaxSessionFee:     t.max,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].AverageSessionFee != summaries[j].AverageSessionFee {
+			return summaries[i].AverageSessionFee > summaries[j].AverageSessionFee
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries
+}
+
+// ExportPriceReport 체인별, 점포별, 활동 분류별 수강료 요약을 CSV 파일에 저장한다.
+func (s *Scrape) ExportPriceReport(fileName string) {
+	log.Println("수집된 문화센터 강좌의 수강료 요약을 CSV 파일에 저장합니다.")
+
+	f, err := os.Create(fileName)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	// 파일 첫 부분에 UTF-8 BOM을 추가한다.
+	_, err = f.WriteString("\xEF\xBB\xBF")
+	utils.CheckErr(err)
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	utils.CheckErr(w.Write([]string{"구분", "이름", "강좌수", "할인 강좌수", "평균 수강료", "평균 1인 1회당 수강료", "최저 1인 1회당 수강료", "최고 1인 1회당 수강료"}))
+
+	for _, kind := range PriceSummaryKinds {
+		for _, ps := range s.PriceSummaries(kind) {
+			utils.CheckErr(w.Write([]string{
+				PriceSummaryKindName(kind),
+				ps.Name,
+				fmt.Sprintf("%d", ps.LectureCount),
+				fmt.Sprintf("%d", ps.DiscountedCount),
+				formatFee(ps.AverageFee),
+				formatFee(ps.AverageSessionFee),
+				formatFee(ps.MinSessionFee),
+				formatFee(ps.MaxSessionFee),
+			}))
+		}
+	}
+
+	log.Printf("수집된 문화센터 강좌의 수강료 요약을 CSV 파일(%s)에 저장하였습니다.", fileName)
+}
+
+// PriceSummaryKindName 수강료 요약 구분의 한글 이름을 반환한다.
+func PriceSummaryKindName(kind string) string {
+	switch kind {
+	case PriceByChain:
+		return "체인"
+	case PriceByStore:
+		return "점포"
+	case PriceByCategory:
+		return "활동분류"
+	}
+	return kind
+}
+
+// formatFee 금액을 천 단위 구분 기호와 원 단위로 표시한다(예:60,000원). 금액이 0이면 빈 문자열을 반환한다.
+func formatFee(fee int) string {
+	if fee == 0 {
+		return ""
+	}
+	return utils.FormatCommas(fee) + "원"
+}
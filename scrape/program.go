@@ -0,0 +1,335 @@
+package scrape
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 같은 프로그램으로 판단하는 정규화된 강좌명의 최소 유사도(0~1)
+const programSimilarity = 0.8
+
+// 유사도로 같은 프로그램을 판단할 정규화된 강좌명의 최소 길이(글자수)
+// 짧은 강좌명(예:발레, 미술)은 유사도가 높아도 다른 프로그램일 수 있으므로 정확히 같은 경우에만 같은 프로그램으로 판단한다.
+const programSimilarityMinLength = 4
+
+var (
+	// 괄호로 감싼 부분(예:[5~7세], (토), <신규>)
+	programBracketRegexp = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|<[^>]*>|【[^】]*】|「[^」]*」|\{[^}]*\}`)
+
+	// 연령, 학년, 출생연도, 강좌횟수(예:5~7세, 24개월이상, 초1~3, 2019년생, 12회)
+	programAgeRegexp = regexp.MustCompile(`\d+\s*(~\s*\d+\s*)?(세|살|개월)\s*(이상|이하|미만)?|초\s*\d(\s*~\s*(초\s*)?\d)?(학년)?|\d{2,4}\s*(~\s*\d{2,4}\s*)?년생|\d+\s*회`)
+
+	// 강좌명 끝에 붙는 반, 클래스 등의 표현
+	programSuffixRegexp = regexp.MustCompile(`(반|클래스|교실)$`)
+)
+
+// Program 여러 점포 또는 체인에서 운영되는 같은 프로그램의 강좌 목록
+type Program struct {
+	Key      string             `json:"key"`      // 프로그램 키(정규화된 강좌명)
+	Title    string             `json:"title"`    // 대표 강좌명(가장 많이 사용된 강좌명)
+	Category lectures.Category  `json:"category"` // 활동 분류
+	Stores   []string           `json:"stores"`   // 프로그램이 운영되는 점포 목록
+	Lectures []lectures.Lecture `json:"lectures"` // 프로그램의 강좌 목록(점포, 요일, 시간 순서)
+}
+
+// Franchise 여러 점포에서 운영되는 프로그램(프랜차이즈 강좌)인지의 여부를 반환한다.
+func (p *Program) Franchise() bool {
+	return len(p.Stores) > 1
+}
+
+// NormalizeTitle 같은 프로그램을 찾기 위해 강좌명을 정규화한다.
+// 괄호로 감싼 부분, 연령 및 강좌횟수, 강좌명 끝의 반, 클래스 등의 표현, 공백, 이모지 및 기호를 제거하고 소문자로 변환한다.
+func NormalizeTitle(title string) string {
+	s := programBracketRegexp.ReplaceAllString(title, " ")
+	s = programAgeRegexp.ReplaceAllString(s, " ")
+
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) == true || unicode.IsDigit(r) == true {
+			sb.WriteRune(r)
+		}
+	}
+	s = sb.String()
+
+	if trimmed := programSuffixRegexp.ReplaceAllString(s, ""); len([]rune(trimmed)) >= 2 {
+		s = trimmed
+	}
+
+	// 강좌명이 모두 제거되면(예:[5~7세]) 원래 강좌명을 사용한다.
+	if s == "" {
+		s = strings.ToLower(strings.Join(strings.Fields(title), ""))
+	}
+
+	return s
+}
+
+// GroupPrograms 강좌명을 정규화하여 같은 프로그램의 강좌를 찾고 강좌의 프로그램 키를 지정한다.
+// 정규화된 강좌명이 같거나 충분히 유사하면(예:오타) 점포 및 체인이 달라도 같은 프로그램으로 판단한다.
+func (s *Scrape) GroupPrograms() {
+	// 정규화된 강좌명별로 강좌를 모은 후, 유사한 강좌명을 하나의 프로그램으로 합친다.
+	keys := make(map[string][]int)
+	var keyOrder []string
+	for i := range s.lectures {
+		key := NormalizeTitle(s.lectures[i].Title)
+		if _, exists := keys[key]; exists == false {
+			keyOrder = append(keyOrder, key)
+		}
+		keys[key] = append(keys[key], i)
+	}
+
+	// 강좌가 많은 정규화된 강좌명부터 차례대로, 이미 정해진 프로그램 키 중에서 직접 유사한 첫 번째 프로그램에 합치고
+	// 유사한 프로그램 키가 없으면 새로운 프로그램 키로 정한다.
+	// 프로그램 키와 직접 유사한 강좌명만 합치므로, 조금씩 다른 강좌명이 이어져 서로 다른 프로그램이 하나로 합쳐지지 않는다.
+	sort.SliceStable(keyOrder, func(i, j int) bool {
+		return len(keys[keyOrder[i]]) > len(keys[keyOrder[j]])
+	})
+
+	var programKeys []string
+	members := make(map[string][]string)
+	for _, key := range keyOrder {
+		programKey := key
+		for _, other := range programKeys {
+			if similarTitles(key, other) == true {
+				programKey = other
+				break
+			}
+		}
+		if programKey == key {
+			programKeys = append(programKeys, key)
+		}
+		members[programKey] = append(members[programKey], key)
+	}
+
+	programCount, franchiseCount := 0, 0
+	for _, programKey := range programKeys {
+		stores := make(map[string]bool)
+		for _, key := range members[programKey] {
+			for _, i := range keys[key] {
+				s.lectures[i].Program = programKey
+				stores[s.lectures[i].StoreName] = true
+			}
+		}
+
+		programCount++
+		if len(stores) > 1 {
+			franchiseCount++
+		}
+	}
+
+	log.Printf("총 %d개의 강좌를 %d개의 프로그램으로 분류하였습니다(여러 점포에서 운영되는 프로그램:%d개).", len(s.lectures), programCount, franchiseCount)
+}
+
+// Programs 필터링되지 않은 강좌를 프로그램별로 모아 반환한다.
+// 여러 점포에서 운영되는 프로그램, 강좌가 많은 프로그램 순서로 정렬된다.
+func (s *Scrape) Programs() []Program {
+	programMap := make(map[string]*Program)
+	titleCounts := make(map[string]map[string]int)
+	for _, lecture := range s.lectures {
+		if lecture.ScrapeExcluded == true {
+			continue
+		}
+
+		key := lecture.Program
+		if key == "" {
+			key = NormalizeTitle(lecture.Title)
+		}
+
+		p, exists := programMap[key]
+		if exists == false {
+			p = &Program{Key: key, Category: lecture.Category}
+			programMap[key] = p
+			titleCounts[key] = make(map[string]int)
+		}
+		p.Lectures = append(p.Lectures, lecture)
+		if utils.Contains(p.Stores, lecture.StoreName) == false {
+			p.Stores = append(p.Stores, lecture.StoreName)
+		}
+		titleCounts[key][lecture.Title]++
+	}
+
+	programs := make([]Program, 0, len(programMap))
+	for key, p := range programMap {
+		for title, count := range titleCounts[key] {
+			if count > titleCounts[key][p.Title] || (count == titleCounts[key][p.Title] && title < p.Title) {
+				p.Title = title
+			}
+		}
+
+		sort.Strings(p.Stores)
+		sort.SliceStable(p.Lectures, func(i, j int) bool {
+			a, b := p.Lectures[i], p.Lectures[j]
+			if a.StoreName != b.StoreName {
+				return a.StoreName < b.StoreName
+			}
+			if dayOfTheWeekOrder(a.DayOfTheWeek) != dayOfTheWeekOrder(b.DayOfTheWeek) {
+				return dayOfTheWeekOrder(a.DayOfTheWeek) < dayOfTheWeekOrder(b.DayOfTheWeek)
+			}
+			return a.StartTime < b.StartTime
+		})
+
+		programs = append(programs, *p)
+	}
+	sort.Slice(programs, func(i, j int) bool {
+		if len(programs[i].Stores) != len(programs[j].Stores) {
+			return len(programs[i].Stores) > len(programs[j].Stores)
+		}
+		if len(programs[i].Lectures) != len(programs[j].Lectures) {
+			return len(programs[i].Lectures) > len(programs[j].Lectures)
+		}
+		return programs[i].Title < programs[j].Title
+	})
+
+	return programs
+}
+
+// ExportProgramsCSV 필터링되지 않은 강좌를 프로그램당 한 행으로 CSV 파일에 저장한다.
+// 프로그램을 운영하는 점포별 요일, 시간, 접수상태가 선택 가능한 항목으로 함께 저장된다.
+func (s *Scrape) ExportProgramsCSV(fileName string) {
+	log.Println("수집된 문화센터 강좌를 프로그램별로 CSV 파일에 저장합니다.")
+
+	programs := s.Programs()
+
+	f, err := os.Create(fileName)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	// 파일 첫 부분에 UTF-8 BOM을 추가한다.
+	_, err = f.WriteString("\xEF\xBB\xBF")
+	utils.CheckErr(err)
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	utils.CheckErr(w.Write([]string{"프로그램", "활동분류", "점포수", "강좌수", "수강료", "선택 가능한 강좌", "강좌ID"}))
+
+	for _, p := range programs {
+		var options, ids []string
+		minPrice, maxPrice := -1, -1
+		for _, l := range p.Lectures {
+			options = append(options, fmt.Sprintf("%s %s %s~%s(%s)", l.StoreName, l.DayOfTheWeek, l.StartTime, l.EndTime, l.Status))
+			ids = append(ids, l.ID)
+
+			if price, ok := l.PriceValue(); ok == true {
+				if minPrice == -1 || price < minPrice {
+					minPrice = price
+				}
+				if maxPrice == -1 || price > maxPrice {
+					maxPrice = price
+				}
+			}
+		}
+
+		price := ""
+		if minPrice != -1 {
+			price = fmt.Sprintf("%s원", utils.FormatCommas(minPrice))
+			if maxPrice != minPrice {
+				price = fmt.Sprintf("%s~%s원", utils.FormatCommas(minPrice), utils.FormatCommas(maxPrice))
+			}
+		}
+
+		utils.CheckErr(w.Write([]string{
+			p.Title,
+			p.Category.Name(),
+			fmt.Sprintf("%d", len(p.Stores)),
+			fmt.Sprintf("%d", len(p.Lectures)),
+			price,
+			strings.Join(options, "\n"),
+			strings.Join(ids, "\n"),
+		}))
+	}
+
+	log.Printf("수집된 문화센터 강좌를 프로그램별(%d건)로 CSV 파일(%s)에 저장하였습니다.", len(programs), fileName)
+}
+
+// ExportProgramsJSON 필터링되지 않은 강좌를 프로그램별로 JSON 파일에 저장한다.
+func (s *Scrape) ExportProgramsJSON(fileName string) {
+	log.Println("수집된 문화센터 강좌를 프로그램별로 JSON 파일에 저장합니다.")
+
+	programs := s.Programs()
+
+	data, err := json.MarshalIndent(programs, "", "  ")
+	utils.CheckErr(err)
+
+	utils.CheckErr(os.WriteFile(fileName, data, 0644))
+
+	log.Printf("수집된 문화센터 강좌를 프로그램별(%d건)로 JSON 파일(%s)에 저장하였습니다.", len(programs), fileName)
+}
+
+// similarTitles 정규화된 두 강좌명이 같은 프로그램으로 판단할 만큼 유사한지의 여부를 반환한다.
+// 강좌명에 포함된 숫자가 다르면(예:발레 1단계, 발레 2단계) 같은 과정의 다른 단계이므로 유사하지 않은 것으로 판단한다.
+func similarTitles(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < programSimilarityMinLength || len(rb) < programSimilarityMinLength {
+		return false
+	}
+	if titleDigits(ra) != titleDigits(rb) {
+		return false
+	}
+
+	longer, shorter := len(ra), len(rb)
+	if shorter > longer {
+		longer, shorter = shorter, longer
+	}
+
+	// 길이 차이만으로 유사도를 만족할 수 없으면 편집 거리를 계산하지 않는다.
+	if 1-float64(longer-shorter)/float64(longer) < programSimilarity {
+		return false
+	}
+
+	return 1-float64(levenshtein(ra, rb))/float64(longer) >= programSimilarity
+}
+
+// titleDigits 강좌명에 포함된 숫자만 차례대로 모아 반환한다.
+func titleDigits(title []rune) string {
+	var sb strings.Builder
+	for _, r := range title {
+		if unicode.IsDigit(r) == true {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// levenshtein 두 문자열의 편집 거리를 반환한다.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// dayOfTheWeekOrder 요일(예:월, 월요일)의 순서(월요일:0 ~ 일요일:6)를 반환한다.
+func dayOfTheWeekOrder(dayOfTheWeek string) int {
+	for i, d := range []string{"월", "화", "수", "목", "금", "토", "일"} {
+		if strings.HasPrefix(dayOfTheWeek, d) == true {
+			return i
+		}
+	}
+	return 7
+}
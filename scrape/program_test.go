@@ -0,0 +1,65 @@
+package scrape
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"testing"
+)
+
+func TestSimilarTitles(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"창의미술놀이", "창의미술놀이", true},
+		{"창의미술놀이", "창의미슬놀이", true},   // 오타
+		{"발레1단계", "발레2단계", false},    // 같은 과정의 다른 단계
+		{"피아노레벨1", "피아노레벨12", false}, // 숫자가 추가된 경우
+		{"발레", "빌레", false},          // 짧은 강좌명은 정확히 같아야 한다
+		{"창의미술놀이", "창의음악놀이", false},  // 유사도 미달
+	}
+
+	for _, tt := range tests {
+		if got := similarTitles(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarTitles(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGroupPrograms(t *testing.T) {
+	lecture := func(storeName, title string) lectures.Lecture {
+		return lectures.Lecture{StoreName: storeName, Title: title}
+	}
+
+	s := &Scrape{lectures: []lectures.Lecture{
+		lecture("이마트 여수점", "[5~7세] 발레 1단계"),
+		lecture("이마트 순천점", "발레 1단계(토)"),
+		lecture("롯데마트 여수점", "발레 2단계"),
+		lecture("이마트 여수점", "가나다라마바사아"),
+		lecture("이마트 순천점", "가나다라마바사아"),
+		lecture("롯데마트 여수점", "가나다라마바사자"),
+		lecture("홈플러스 순천점", "가나다라마바차자"),
+	}}
+	s.GroupPrograms()
+
+	programs := make(map[string]string)
+	for _, l := range s.lectures {
+		programs[l.StoreName+"/"+l.Title] = l.Program
+	}
+
+	// 단계가 다른 강좌는 같은 프로그램으로 합치지 않는다.
+	if programs["이마트 여수점/[5~7세] 발레 1단계"] != programs["이마트 순천점/발레 1단계(토)"] {
+		t.Errorf("같은 단계의 강좌가 다른 프로그램으로 분류되었습니다(%v)", programs)
+	}
+	if programs["이마트 여수점/[5~7세] 발레 1단계"] == programs["롯데마트 여수점/발레 2단계"] {
+		t.Errorf("다른 단계의 강좌가 같은 프로그램(%s)으로 분류되었습니다", programs["롯데마트 여수점/발레 2단계"])
+	}
+
+	// 가나다라마바사아 ~ 가나다라마바사자 ~ 가나다라마바차자는 이웃한 강좌명끼리만 유사하므로,
+	// 프로그램 키와 직접 유사한 강좌명만 합치고 이어서 합치지 않는다.
+	if got := programs["롯데마트 여수점/가나다라마바사자"]; got != "가나다라마바사아" {
+		t.Errorf("가나다라마바사자의 프로그램 = %q, want 가나다라마바사아", got)
+	}
+	if got := programs["홈플러스 순천점/가나다라마바차자"]; got != "가나다라마바차자" {
+		t.Errorf("가나다라마바차자의 프로그램 = %q, want 가나다라마바차자", got)
+	}
+}
@@ -2,7 +2,12 @@ package scrape
 
 import (
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/age"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/ics"
 	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
 	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures/culture"
 	"github.com/darkkaiser/culturelecture-scrape/utils"
@@ -10,8 +15,11 @@ import (
 	"math"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -31,24 +39,203 @@ type AgeLimitRange struct {
 	to     int
 }
 
+// CSV 파일의 항목명
+var csvHeaders = []string{"강좌ID", "점포", "강좌그룹", "강좌명", "강사명", "개강일", "시작시간", "종료시간", "요일", "수강료", "강좌횟수", "접수상태", "상세페이지", "수강자", "제외사유", "활동분류", "정가", "재료비", "1인 1회당 수강료", "점포주소", "거리(km)", "이동시간(분)"}
+
+// csvOptionalHeaders 나중에 추가되어 이전 버전의 CSV 파일에는 존재하지 않을 수 있는 항목 목록
+var csvOptionalHeaders = []string{"강좌ID", "수강자", "제외사유", "활동분류", "정가", "재료비", "1인 1회당 수강료", "점포주소", "거리(km)", "이동시간(분)"}
+
 type Scrape struct {
+	config *config.Config
+
+	chains []string // 수집할 문화센터 체인 ID 목록(빈 목록이면 전체 체인)
+
 	lectures []lectures.Lecture
 }
 
-func New() *Scrape {
-	return &Scrape{}
+func New(config *config.Config) *Scrape {
+	return &Scrape{
+		config: config,
+	}
+}
+
+// SetChains 수집할 문화센터 체인을 지정한다. 지정하지 않으면 전체 체인을 수집한다.
+func (s *Scrape) SetChains(chains []string) {
+	for _, chain := range chains {
+		s.config.Chain(chain)
+	}
+	s.chains = chains
 }
 
 type Scraper interface {
-	ScrapeCultureLectures(mainC chan<- []lectures.Lecture)
+	Chain() string
+	Name() string
+	ScrapeCultureLectures() ([]lectures.Lecture, error)
+	EnrichCultureLecture(lecture *lectures.Lecture, cache *culture.DetailPageCache) error
+	CultureLectureGroups() ([]lectures.Group, error)
+	Doctor() []lectures.Check
 }
 
-func (s *Scrape) Scrape(searchYear string, searchSeason string) {
+// Scrape 문화센터 강좌를 수집한다.
+// 문화센터 사이트 요청 또는 응답 파싱이 실패하면 수집을 중단하고 오류를 반환하며, 이전에 수집된 강좌 목록은 그대로 유지된다.
+func (s *Scrape) Scrape(searchYear string, searchSeason string) error {
 	searchYear = utils.CleanString(searchYear)
 	searchSeason = utils.CleanString(searchSeason)
 
 	log.Printf("문화센터 강좌 수집을 시작합니다.(검색조건:%s년도 %s)", searchYear, searchSeason)
 
+	scrapers := s.Scrapers(searchYear, searchSeason)
+
+	type result struct {
+		lectures []lectures.Lecture
+		err      error
+	}
+
+	c := make(chan result, len(scrapers))
+	for _, scraper := range scrapers {
+		go func(scraper Scraper) {
+			lectureList, err := scraper.ScrapeCultureLectures()
+			if err != nil {
+				err = fmt.Errorf("%s 문화센터 강좌 수집이 실패하였습니다: %w", scraper.Name(), err)
+			}
+			c <- result{lectures: lectureList, err: err}
+		}(scraper)
+	}
+
+	var scraped []lectures.Lecture
+	var errs []error
+	for i := 0; i < len(scrapers); i++ {
+		r := <-c
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		scraped = append(scraped, r.lectures...)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	s.lectures = scraped
+
+	s.dedupe()
+
+	log.Printf("문화센터 강좌 수집이 완료되었습니다. 총 %d개의 강좌가 수집되었습니다.", len(s.lectures))
+
+	s.Classify()
+	s.GroupPrograms()
+
+	s.warnUnknownReceptionStatuses()
+
+	if s.config.Detail.Enabled == true {
+		s.enrich(scrapers)
+	}
+
+	s.NormalizePrices()
+	s.Locate()
+
+	return nil
+}
+
+// dedupe 강좌 ID가 같은 강좌가 여러번 수집된 경우(여러 강좌군에 동시에 속한 강좌 등) 처음 수집된 강좌만 남긴다.
+func (s *Scrape) dedupe() {
+	exists := make(map[string]bool)
+
+	dedupedLectures := s.lectures[:0]
+	for _, lecture := range s.lectures {
+		if exists[lecture.ID] == true {
+			continue
+		}
+		exists[lecture.ID] = true
+		dedupedLectures = append(dedupedLectures, lecture)
+	}
+
+	if duplicatedCount := len(s.lectures) - len(dedupedLectures); duplicatedCount > 0 {
+		log.Printf("강좌 ID가 중복된 %d개의 강좌를 제외하였습니다.", duplicatedCount)
+	}
+
+	s.lectures = dedupedLectures
+}
+
+// enrich 강좌 상세페이지를 수집하여 강좌의 커리큘럼, 수강대상, 준비물, 강의실, 강의일자를 채운다.
+// 상세페이지는 캐시되며, 설정된 갯수만큼만 동시에 요청한다.
+func (s *Scrape) enrich(scrapers []Scraper) {
+	log.Printf("문화센터 강좌 상세페이지 수집을 시작합니다.(동시 요청 갯수:%d)", s.config.Detail.Concurrency)
+
+	scraperMap := make(map[string]Scraper)
+	for _, scraper := range scrapers {
+		scraperMap[scraper.Chain()] = scraper
+	}
+
+	cache := culture.NewDetailPageCache(s.config.Detail.CacheDir, s.config.Detail.CacheTTLDuration())
+
+	var wait sync.WaitGroup
+	var failedCount int64 = 0
+
+	sem := make(chan struct{}, s.config.Detail.Concurrency)
+	for i := range s.lectures {
+		wait.Add(1)
+		sem <- struct{}{}
+
+		go func(lecture *lectures.Lecture) {
+			defer wait.Done()
+			defer func() { <-sem }()
+
+			if err := scraperMap[lecture.Chain].EnrichCultureLecture(lecture, cache); err != nil {
+				atomic.AddInt64(&failedCount, 1)
+				log.Printf(" >> 강좌 상세페이지 수집 실패, 상세정보 없이 진행합니다.(%s : %s, %s)", lecture.StoreName, lecture.Title, err)
+			}
+		}(&s.lectures[i])
+	}
+
+	wait.Wait()
+
+	log.Printf("문화센터 강좌 상세페이지 수집이 완료되었습니다. 총 %d개의 강좌중에서 %d개의 강좌 상세페이지 수집이 실패하였습니다.", len(s.lectures), failedCount)
+}
+
+// warnUnknownReceptionStatuses 지원하지 않는 접수상태 문구로 인해 접수상태가 알수없음으로 처리된 강좌를 요약하여 출력한다.
+func (s *Scrape) warnUnknownReceptionStatuses() {
+	var keys []string
+	unknownStatusCounts := make(map[string]int)
+	for _, lecture := range s.lectures {
+		if lecture.Status != lectures.ReceptionStatusUnknown {
+			continue
+		}
+
+		key := fmt.Sprintf("%s : '%s'", lecture.StoreName, lecture.StatusText)
+		if _, exists := unknownStatusCounts[key]; exists == false {
+			keys = append(keys, key)
+		}
+		unknownStatusCounts[key]++
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.Strings(keys)
+
+	log.Printf("지원하지 않는 접수상태 문구가 발견되어 해당 강좌의 접수상태를 '%s'(으)로 처리하였습니다. 설정 파일의 'statuses' 항목에 접수상태 문구를 추가하세요.", lectures.ReceptionStatusUnknown)
+	for _, key := range keys {
+		log.Printf(" >> %s (%d건)", key, unknownStatusCounts[key])
+	}
+}
+
+// SetLectures 스냅샷 저장소 등에서 읽어들인 강좌 목록을 수집된 강좌 목록으로 지정한다.
+// 강좌를 다시 수집하지 않고 Filter, ExportCSV 등을 사용할 때 사용한다.
+func (s *Scrape) SetLectures(lectureList []lectures.Lecture) {
+	s.lectures = lectureList
+}
+
+// Lectures 수집된 강좌 목록을 반환한다.
+func (s *Scrape) Lectures() []lectures.Lecture {
+	return s.lectures
+}
+
+// Scrapers 검색조건에 해당하는 문화센터 체인별 수집기 목록을 반환한다.
+func (s *Scrape) Scrapers(searchYear string, searchSeason string) []Scraper {
+	searchYear = utils.CleanString(searchYear)
+	searchSeason = utils.CleanString(searchSeason)
+
 	if searchYear == "" || searchSeason == "" {
 		log.Fatalf("검색년도 및 검색시즌은 빈 문자열을 허용하지 않습니다(검색년도:%s, 검색시즌:%s)", searchYear, searchSeason)
 	}
@@ -68,83 +255,51 @@ func (s *Scrape) Scrape(searchYear string, searchSeason string) {
 		log.Fatalf("입력된 검색시즌이 올바르지 않습니다(검색시즌:%s)", searchSeason)
 	}
 
-	scrapers := []Scraper{
-		culture.NewHomeplus(),
-		culture.NewLottemart(searchYear, searchSeasonCode),
-		culture.NewEmart(searchYear),
+	var scrapers []Scraper
+	if s.selectedChain(config.ChainHomeplus) == true {
+		scrapers = append(scrapers, culture.NewHomeplus(s.config.Chain(config.ChainHomeplus)))
 	}
-
-	c := make(chan []lectures.Lecture, len(scrapers))
-	for _, scraper := range scrapers {
-		go scraper.ScrapeCultureLectures(c)
+	if s.selectedChain(config.ChainLottemart) == true {
+		scrapers = append(scrapers, culture.NewLottemart(searchYear, searchSeasonCode, s.config.Chain(config.ChainLottemart)))
 	}
-
-	s.lectures = nil
-	for i := 0; i < len(scrapers); i++ {
-		scrapedCultureLectures := <-c
-		s.lectures = append(s.lectures, scrapedCultureLectures...)
+	if s.selectedChain(config.ChainEmart) == true {
+		scrapers = append(scrapers, culture.NewEmart(searchYear, s.config.Chain(config.ChainEmart)))
 	}
 
-	log.Printf("문화센터 강좌 수집이 완료되었습니다. 총 %d개의 강좌가 수집되었습니다.", len(s.lectures))
+	return scrapers
 }
 
-func (s *Scrape) Filter(cultureLecturerMonths int, cultureLecturerAge int, holidays []string) {
-	// 접수상태가 접수마감인 강좌를 제외한다.
-	for i, lecture := range s.lectures {
-		if lecture.Status == lectures.ReceptionStatusClosed {
-			s.lectures[i].ScrapeExcluded = true
-		}
-	}
-
-	// 주말 및 공휴일이 아닌 평일 16시 이전의 강좌를 제외한다.
-	weekdays := []string{"월요일", "화요일", "수요일", "목요일", "금요일"}
-	for i, lecture := range s.lectures {
-		if utils.Contains(weekdays, lecture.DayOfTheWeek) == true && utils.Contains(holidays, lecture.StartDate) == false {
-			h24, err := strconv.Atoi(lecture.StartTime[:2])
-			utils.CheckErr(err)
+func (s *Scrape) selectedChain(chain string) bool {
+	return len(s.chains) == 0 || utils.Contains(s.chains, chain) == true
+}
 
-			if h24 < 16 {
-				s.lectures[i].ScrapeExcluded = true
-			}
-		}
-	}
+// ageConvention 체인의 강좌 연령제한에 사용되는 나이 계산 방식을 반환한다.
+func (s *Scrape) ageConvention(chain string) age.Convention {
+	return s.config.Chains[chain].Convention()
+}
 
-	// 강좌명에 특정 문자열이 포함되어 있는 경우 수집에서 제외한다.
-	for i, lecture := range s.lectures {
-		for _, v := range []string{"키즈발레", "영어발레", "엔젤발레", "엔젤 발레", "체형교정발레", "체형교정 발레", "YSM발레", "YSM 발레", "쁘띠발레", "발레리나", "앨리스 스토리텔링 발레", "트윈클 동화발레", "밸리댄스", "[광주국제영어마을"} {
-			if strings.Contains(lecture.Title, v) == true {
-				s.lectures[i].ScrapeExcluded = true
-				break
-			}
+// extractMonthsOrAgeRange 강좌의 연령제한타입 및 범위를 추출한다.
+// 출생연도 및 학년으로 지정된 연령제한은 기준일(at) 현재 나이 계산 방식(convention)에 따른 나이 범위로 변환된다.
+func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture, convention age.Convention, at time.Time) (AgeLimitType, int, int) {
+	// 강좌명에서 연령을 추출하지 못한 경우 상세페이지의 수강대상에서 연령을 추출한다.
+	for _, text := range []string{lecture.Title, lecture.TargetAge} {
+		if text == "" {
+			continue
 		}
-	}
-
-	// 개월수 및 나이에 포함되지 않는 강좌는 제외한다.
-	for i, lecture := range s.lectures {
-		alType, from, to := s.extractMonthsOrAgeRange(&lecture)
 
-		if alType == AgeLimitMonths {
-			if cultureLecturerMonths < from || cultureLecturerMonths > to {
-				s.lectures[i].ScrapeExcluded = true
-			}
-		} else if alType == AgeLimitAge {
-			if cultureLecturerAge < from || cultureLecturerAge > to {
-				s.lectures[i].ScrapeExcluded = true
-			}
+		if alType, from, to := s.extractMonthsOrAgeRangeFromText(text, convention, at); alType != AgeLimitUnknwon {
+			return alType, from, to
 		}
 	}
 
-	excludedLectureCount := 0
-	for _, lecture := range s.lectures {
-		if lecture.ScrapeExcluded == true {
-			excludedLectureCount++
-		}
+	if lecture.ScrapeExcluded == false {
+		log.Printf(" >> 수집된 강좌의 연령(나이, 개월수) 추출 실패, 필터링 대상에서 제외됩니다.(%s : %s)", lecture.StoreName, lecture.Title)
 	}
 
-	log.Printf("총 %d건의 문화센터 강좌중에서 %d건이 필터링되어 제외되었습니다.", len(s.lectures), excludedLectureCount)
+	return AgeLimitUnknwon, 0, math.MaxInt32
 }
 
-func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitType, int, int) {
+func (s *Scrape) extractMonthsOrAgeRangeFromText(text string, convention age.Convention, at time.Time) (AgeLimitType, int, int) {
 	alTypesMap := map[AgeLimitType]string{
 		AgeLimitAge:    "세",
 		AgeLimitMonths: "개월",
@@ -153,7 +308,7 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 		// n세이상, n세 이상, n세~성인, n세~ 성인, n세~누구나, n세~ 누구나
 		// n개월이상, n개월 이상, n개월~성인, n개월~ 성인, n개월~누구나, n개월~ 누구나
 		for _, v := range []string{alTypeString + "이상", alTypeString + " 이상", alTypeString + "~성인", alTypeString + "~ 성인", alTypeString + "~누구나", alTypeString + "~ 누구나"} {
-			fs := regexp.MustCompile("[0-9]{1,2}" + v).FindString(lecture.Title)
+			fs := regexp.MustCompile("[0-9]{1,2}" + v).FindString(text)
 			if len(fs) > 0 {
 				from, err := strconv.Atoi(strings.ReplaceAll(fs, v, ""))
 				utils.CheckErr(err)
@@ -164,7 +319,7 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 
 		// a~b세, a-b세, a세~b세, a세-b세
 		// a~b개월, a-b개월, a개월~b개월, a개월-b개월
-		fs := regexp.MustCompile(fmt.Sprintf("[0-9]{1,2}[%s]?[~-]{1}[0-9]{1,2}%s", alTypeString, alTypeString)).FindString(lecture.Title)
+		fs := regexp.MustCompile(fmt.Sprintf("[0-9]{1,2}[%s]?[~-]{1}[0-9]{1,2}%s", alTypeString, alTypeString)).FindString(text)
 		if len(fs) > 0 {
 			split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, alTypeString, ""), "-", "~"), "~")
 
@@ -182,14 +337,14 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 
 		// n세~초등, n세-초등
 		// n개월~초등, n개월-초등
-		fs = regexp.MustCompile(fmt.Sprintf("[0-9]{1,2}%s[~-]{1}초등", alTypeString)).FindString(lecture.Title)
+		fs = regexp.MustCompile(fmt.Sprintf("[0-9]{1,2}%s[~-]{1}초등", alTypeString)).FindString(text)
 		if len(fs) > 0 {
 			split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, alTypeString, ""), "-", "~"), "~")
 
 			from, err := strconv.Atoi(split[0])
 			utils.CheckErr(err)
 
-			to := 13
+			_, to := convention.GradeRange(6, at)
 			if alType == AgeLimitMonths {
 				to *= 12
 			}
@@ -199,17 +354,17 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 
 		// n세~초n, n세-초n
 		// n개월~초n, n개월-초n
-		fs = regexp.MustCompile(fmt.Sprintf("[0-9]{1,2}%s[~-]{1}초[1-6]{1}", alTypeString)).FindString(lecture.Title)
+		fs = regexp.MustCompile(fmt.Sprintf("[0-9]{1,2}%s[~-]{1}초[1-6]{1}", alTypeString)).FindString(text)
 		if len(fs) > 0 {
 			split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, alTypeString, ""), "-", "~"), "~")
 
 			from, err := strconv.Atoi(split[0])
 			utils.CheckErr(err)
 
-			to, err := strconv.Atoi(strings.ReplaceAll(split[1], "초", ""))
+			grade, err := strconv.Atoi(strings.ReplaceAll(split[1], "초", ""))
 			utils.CheckErr(err)
 
-			to += 7
+			_, to := convention.GradeRange(grade, at)
 			if alType == AgeLimitMonths {
 				to *= 12
 			}
@@ -219,7 +374,7 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 
 		// (n세)
 		// (n개월)
-		fs = regexp.MustCompile(fmt.Sprintf("\\([0-9]{1,2}%s\\)", alTypeString)).FindString(lecture.Title)
+		fs = regexp.MustCompile(fmt.Sprintf("\\([0-9]{1,2}%s\\)", alTypeString)).FindString(text)
 		if len(fs) > 0 {
 			no, err := strconv.Atoi(strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(fs, alTypeString, ""), "(", ""), ")", ""))
 			utils.CheckErr(err)
@@ -229,7 +384,7 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 	}
 
 	// 초a~초b, 초a-초b
-	fs := regexp.MustCompile("초[1-6][~-]초[1-6]").FindString(lecture.Title)
+	fs := regexp.MustCompile("초[1-6][~-]초[1-6]").FindString(text)
 	if len(fs) > 0 {
 		split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, "초", ""), "-", "~"), "~")
 
@@ -238,17 +393,18 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 		value2, err := strconv.Atoi(split[1])
 		utils.CheckErr(err)
 
-		if value1 < value2 {
-			return AgeLimitAge, value1 + 7, value2 + 7
-		} else {
-			return AgeLimitAge, value2 + 7, value1 + 7
+		if value1 > value2 {
+			value1, value2 = value2, value1
 		}
-	}
 
-	now := time.Now()
+		from, _ := convention.GradeRange(value1, at)
+		_, to := convention.GradeRange(value2, at)
+
+		return AgeLimitAge, from, to
+	}
 
 	// nnnn~nnnn년생, nnnn년~nnnn년생
-	fs = regexp.MustCompile("[0-9]{4}년?~[0-9]{4}년생").FindString(lecture.Title)
+	fs = regexp.MustCompile("[0-9]{4}년?~[0-9]{4}년생").FindString(text)
 	if len(fs) > 0 {
 		split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, "년생", ""), "년", ""), "~")
 
@@ -257,18 +413,13 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 		value2, err := strconv.Atoi(split[1])
 		utils.CheckErr(err)
 
-		value1 = now.Year() - value1 + 1
-		value2 = now.Year() - value2 + 1
+		from, to := birthYearsAgeRange(convention, value1, value2, at)
 
-		if value1 < value2 {
-			return AgeLimitAge, value1, value2
-		} else {
-			return AgeLimitAge, value2, value1
-		}
+		return AgeLimitAge, from, to
 	}
 
 	// nnnn~nn년생, nnnn년~nn년생
-	fs = regexp.MustCompile("[0-9]{4}년?~[0-9]{2}년생").FindString(lecture.Title)
+	fs = regexp.MustCompile("[0-9]{4}년?~[0-9]{2}년생").FindString(text)
 	if len(fs) > 0 {
 		split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, "년생", ""), "년", ""), "~")
 
@@ -277,18 +428,13 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 		value2, err := strconv.Atoi(split[1])
 		utils.CheckErr(err)
 
-		value1 = now.Year() - value1 + 1
-		value2 = now.Year() - (2000 + value2) + 1
+		from, to := birthYearsAgeRange(convention, value1, 2000+value2, at)
 
-		if value1 < value2 {
-			return AgeLimitAge, value1, value2
-		} else {
-			return AgeLimitAge, value2, value1
-		}
+		return AgeLimitAge, from, to
 	}
 
 	// nn~nn년, nn~nn년생
-	fs = regexp.MustCompile("[0-9]{2}~[0-9]{2}년생?").FindString(lecture.Title)
+	fs = regexp.MustCompile("[0-9]{2}~[0-9]{2}년생?").FindString(text)
 	if len(fs) > 0 {
 		split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, "년생", ""), "년", ""), "~")
 
@@ -297,57 +443,63 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 		value2, err := strconv.Atoi(split[1])
 		utils.CheckErr(err)
 
-		value1 = now.Year() - (2000 + value1) + 1
-		value2 = now.Year() - (2000 + value2) + 1
+		from, to := birthYearsAgeRange(convention, 2000+value1, 2000+value2, at)
 
-		if value1 < value2 {
-			return AgeLimitAge, value1, value2
-		} else {
-			return AgeLimitAge, value2, value1
-		}
+		return AgeLimitAge, from, to
 	}
 
 	// nnnn년생 이상
-	fs = regexp.MustCompile("[0-9]{4}년생 이상").FindString(lecture.Title)
+	fs = regexp.MustCompile("[0-9]{4}년생 이상").FindString(text)
 	if len(fs) > 0 {
-		from, err := strconv.Atoi(strings.ReplaceAll(fs, "년생 이상", ""))
+		year, err := strconv.Atoi(strings.ReplaceAll(fs, "년생 이상", ""))
 		utils.CheckErr(err)
 
-		return AgeLimitAge, now.Year() - from + 1, math.MaxInt32
+		from, _ := convention.YearRange(year, at)
+
+		return AgeLimitAge, from, math.MaxInt32
 	}
 
 	// nn년생 이상
-	fs = regexp.MustCompile("[0-9]{2}년생 이상").FindString(lecture.Title)
+	fs = regexp.MustCompile("[0-9]{2}년생 이상").FindString(text)
 	if len(fs) > 0 {
-		from, err := strconv.Atoi(strings.ReplaceAll(fs, "년생 이상", ""))
+		year, err := strconv.Atoi(strings.ReplaceAll(fs, "년생 이상", ""))
 		utils.CheckErr(err)
 
-		return AgeLimitAge, now.Year() - (2000 + from) + 1, math.MaxInt32
+		from, _ := convention.YearRange(2000+year, at)
+
+		return AgeLimitAge, from, math.MaxInt32
 	}
 
 	// 성인~nnnn년
 	// 성인~nnnn년생
-	fs = regexp.MustCompile("성인~[0-9]{4}년생?").FindString(lecture.Title)
+	fs = regexp.MustCompile("성인~[0-9]{4}년생?").FindString(text)
 	if len(fs) > 0 {
 		split := strings.Split(strings.ReplaceAll(strings.ReplaceAll(fs, "년생", ""), "년", ""), "~")
 
-		from, err := strconv.Atoi(split[1])
+		year, err := strconv.Atoi(split[1])
 		utils.CheckErr(err)
 
-		return AgeLimitAge, now.Year() - from + 1, math.MaxInt32
+		from, _ := convention.YearRange(year, at)
+
+		return AgeLimitAge, from, math.MaxInt32
 	}
 
 	// 강좌명에 특정 문자열이 포함되어 있는 경우, 연령제한타입 및 나이 범위를 임의적으로 반환한다.
+	// 학년 및 성인 여부는 기준일 현재 나이 계산 방식에 따른 나이로 변환한다(성인은 연 나이 19세 이상으로 한다).
+	elementaryFrom, _ := convention.GradeRange(1, at)
+	_, elementaryTo := convention.GradeRange(6, at)
+	middleSchoolFrom, _ := convention.GradeRange(7, at)
+	adultFrom, _ := convention.YearRange(at.Year()-19, at)
 	specificTextMap := map[string]AgeLimitRange{
 		"(초등)": {
 			alType: AgeLimitAge,
-			from:   8,
-			to:     13,
+			from:   elementaryFrom,
+			to:     elementaryTo,
 		},
 		"(초등반)": {
 			alType: AgeLimitAge,
-			from:   8,
-			to:     13,
+			from:   elementaryFrom,
+			to:     elementaryTo,
 		},
 		"(모든연령": {
 			alType: AgeLimitAge,
@@ -361,31 +513,39 @@ func (s *Scrape) extractMonthsOrAgeRange(lecture *lectures.Lecture) (AgeLimitTyp
 		},
 		"(초등~성인)": {
 			alType: AgeLimitAge,
-			from:   8,
+			from:   elementaryFrom,
 			to:     math.MaxInt32,
 		},
 		"(성인~중학생이상)": {
 			alType: AgeLimitAge,
-			from:   14,
+			from:   middleSchoolFrom,
 			to:     math.MaxInt32,
 		},
 		"(성인)": {
 			alType: AgeLimitAge,
-			from:   20,
+			from:   adultFrom,
 			to:     math.MaxInt32,
 		},
 	}
 	for k, v := range specificTextMap {
-		if strings.Contains(lecture.Title, k) == true {
+		if strings.Contains(text, k) == true {
 			return v.alType, v.from, v.to
 		}
 	}
 
-	if lecture.ScrapeExcluded == false {
-		log.Printf(" >> 수집된 강좌의 연령(나이, 개월수) 추출 실패, 필터링 대상에서 제외됩니다.(%s : %s)", lecture.StoreName, lecture.Title)
+	return AgeLimitUnknwon, 0, math.MaxInt32
+}
+
+// birthYearsAgeRange 기준일(at) 현재 두 출생연도 사이에 태어난 수강자의 나이 범위를 나이 계산 방식에 따라 계산한다.
+func birthYearsAgeRange(convention age.Convention, birthYear1 int, birthYear2 int, at time.Time) (int, int) {
+	if birthYear1 < birthYear2 {
+		birthYear1, birthYear2 = birthYear2, birthYear1
 	}
 
-	return AgeLimitUnknwon, 0, math.MaxInt32
+	from, _ := convention.YearRange(birthYear1, at)
+	_, to := convention.YearRange(birthYear2, at)
+
+	return from, to
 }
 
 func (s *Scrape) ExportCSV(fileName string) {
@@ -394,6 +554,23 @@ func (s *Scrape) ExportCSV(fileName string) {
 	 */
 	log.Println("수집된 문화센터 강좌 자료를 CSV 파일로 저장합니다.")
 
+	count := s.exportCSV(fileName, false)
+
+	log.Printf("수집된 문화센터 강좌 자료(%d건)를 CSV 파일(%s)로 저장하였습니다.", count, fileName)
+}
+
+// ExportExcludedCSV 필터링되어 제외된 강좌를 제외 사유와 함께 CSV 파일로 저장한다.
+// 필터링 규칙을 조정할 때 어떤 강좌가 어떤 사유로 제외되었는지 확인하는 용도로 사용한다.
+func (s *Scrape) ExportExcludedCSV(fileName string) {
+	log.Println("필터링되어 제외된 문화센터 강좌 자료를 CSV 파일로 저장합니다.")
+
+	count := s.exportCSV(fileName, true)
+
+	log.Printf("필터링되어 제외된 문화센터 강좌 자료(%d건)를 CSV 파일(%s)로 저장하였습니다.", count, fileName)
+}
+
+// exportCSV excluded가 false이면 필터링되지 않은 강좌를, true이면 필터링되어 제외된 강좌를 CSV 파일로 저장하고 저장된 강좌 갯수를 반환한다.
+func (s *Scrape) exportCSV(fileName string, excluded bool) int {
 	f, err := os.Create(fileName)
 	utils.CheckErr(err)
 
@@ -407,16 +584,27 @@ func (s *Scrape) ExportCSV(fileName string) {
 	w := csv.NewWriter(f)
 	defer w.Flush()
 
-	headers := []string{"점포", "강좌그룹", "강좌명", "강사명", "개강일", "시작시간", "종료시간", "요일", "수강료", "강좌횟수", "접수상태", "상세페이지"}
-	utils.CheckErr(w.Write(headers))
+	utils.CheckErr(w.Write(csvHeaders))
 
 	count := 0
 	for _, lecture := range s.lectures {
-		if lecture.ScrapeExcluded == true {
+		if lecture.ScrapeExcluded != excluded {
 			continue
 		}
 
+		var reasons []string
+		for _, r := range lecture.ExcludedReasons {
+			reasons = append(reasons, r.String())
+		}
+
+		// 지원하지 않는 접수상태는 접수상태 원문을 함께 저장한다.
+		status := lecture.Status.String()
+		if lecture.Status == lectures.ReceptionStatusUnknown && lecture.StatusText != "" {
+			status = fmt.Sprintf("%s(%s)", status, lecture.StatusText)
+		}
+
 		r := []string{
+			lecture.ID,
 			lecture.StoreName,
 			lecture.Group,
 			lecture.Title,
@@ -427,12 +615,86 @@ func (s *Scrape) ExportCSV(fileName string) {
 			lecture.DayOfTheWeek,
 			lecture.Price,
 			lecture.Count,
-			lectures.ReceptionStatusString[lecture.Status],
+			status,
 			lecture.DetailPageUrl,
+			strings.Join(lecture.Learners, ", "),
+			strings.Join(reasons, " / "),
+			lecture.Category.Name(),
+			formatFee(lecture.OriginalFee),
+			formatFee(lecture.MaterialFee),
+			formatFee(lecture.SessionFee),
+			lecture.StoreAddress,
+			formatDistance(lecture.Distance),
+			formatMinutes(lecture.TravelMinutes),
 		}
 		utils.CheckErr(w.Write(r))
 		count++
 	}
 
-	log.Printf("수집된 문화센터 강좌 자료(%d건)를 CSV 파일(%s)로 저장하였습니다.", count, fileName)
+	return count
+}
+
+func (s *Scrape) ExportJSON(fileName string) {
+	/**
+	 * JSON 파일저장
+	 */
+	log.Println("수집된 문화센터 강좌 자료를 JSON 파일로 저장합니다.")
+
+	exportLectures := make([]lectures.Lecture, 0, len(s.lectures))
+	for _, lecture := range s.lectures {
+		if lecture.ScrapeExcluded == true {
+			continue
+		}
+		exportLectures = append(exportLectures, lecture)
+	}
+
+	data, err := json.MarshalIndent(exportLectures, "", "  ")
+	utils.CheckErr(err)
+
+	utils.CheckErr(os.WriteFile(fileName, data, 0644))
+
+	log.Printf("수집된 문화센터 강좌 자료(%d건)를 JSON 파일(%s)로 저장하였습니다.", len(exportLectures), fileName)
+}
+
+// ExportICS 필터링되지 않은 강좌의 강의일자 목록을 iCalendar(.ics) 파일로 저장한다.
+func (s *Scrape) ExportICS(fileName string) {
+	log.Println("수집된 문화센터 강좌의 강의일자를 .ics 파일로 저장합니다.")
+
+	var events []ics.Event
+	for _, lecture := range s.lectures {
+		if lecture.ScrapeExcluded == true {
+			continue
+		}
+
+		for i, date := range lecture.SessionDates {
+			start, err := time.ParseInLocation(lectures.DateTimeLayout, date+" "+lecture.StartTime, time.Local)
+			if err != nil {
+				continue
+			}
+			end, err := time.ParseInLocation(lectures.DateTimeLayout, date+" "+lecture.EndTime, time.Local)
+			if err != nil {
+				continue
+			}
+
+			events = append(events, ics.Event{
+				UID:         fmt.Sprintf("session/%s/%s@culturelecture-scrape", lecture.ID, date),
+				Start:       start,
+				End:         end,
+				Summary:     fmt.Sprintf("%s (%d/%d회)", lecture.Title, i+1, len(lecture.SessionDates)),
+				Description: fmt.Sprintf("강사 : %s\n수강료 : %s", lecture.Teacher, lecture.Price),
+				Location:    lecture.StoreName,
+				URL:         lecture.DetailPageUrl,
+			})
+		}
+	}
+
+	f, err := os.Create(fileName)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	utils.CheckErr(ics.Write(f, "문화센터 강좌", events))
+
+	log.Printf("수집된 문화센터 강좌의 강의일자(%d건)를 .ics 파일(%s)로 저장하였습니다.", len(events), fileName)
 }
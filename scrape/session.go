@@ -0,0 +1,81 @@
+package scrape
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/holiday"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ExpandSessionDates 강의일자 목록을 알 수 없는 강좌의 강의일자 목록을 계산한다.
+// 상세페이지에서 수집된 강의일자 목록이 있으면 그대로 사용한다.
+func (s *Scrape) ExpandSessionDates(holidays *holiday.Calendar) {
+	for i := range s.lectures {
+		if len(s.lectures[i].SessionDates) > 0 {
+			continue
+		}
+
+		s.lectures[i].SessionDates = expandSessionDates(&s.lectures[i], holidays, s.config.Chains[s.lectures[i].Chain].SkipHolidays)
+	}
+}
+
+// expandSessionDates 개강일부터 매주 강좌횟수만큼 강의일자를 계산한다.
+// 공휴일에 휴강하면 공휴일은 건너뛰며, 종강일을 알면 종강일 이후의 강의일자는 제외한다.
+// 강좌횟수와 종강일을 모두 알 수 없으면 개강일만 반환한다.
+func expandSessionDates(lecture *lectures.Lecture, holidays *holiday.Calendar, skipHolidays bool) []string {
+	start, ok := lecture.StartDateTime()
+	if ok == false {
+		return nil
+	}
+	end, hasEnd := lecture.EndDateTime()
+
+	count := 0
+	if v := regexp.MustCompile("[0-9]+").FindString(lecture.Count); v != "" {
+		count, _ = strconv.Atoi(v)
+	}
+	if count <= 0 && hasEnd == false {
+		count = 1
+	}
+
+	var dates []string
+	for t := start; count <= 0 || len(dates) < count; t = t.AddDate(0, 0, 7) {
+		if hasEnd == true && t.After(end) == true {
+			break
+		}
+		if skipHolidays == true && holidays.IsHoliday(t) == true {
+			continue
+		}
+
+		dates = append(dates, t.Format("2006-01-02"))
+	}
+
+	return dates
+}
+
+// convenientSessionRatio 강의일자 중에서 주말, 공휴일 또는 16시 이후인 강의일자의 비율을 반환한다.
+// 강의일자 목록 또는 시작시간이 없으면 false를 반환한다.
+func convenientSessionRatio(lecture *lectures.Lecture, holidays *holiday.Calendar) (float64, bool) {
+	if len(lecture.SessionDates) == 0 || len(lecture.StartTime) < 2 {
+		return 0, false
+	}
+
+	afternoon := false
+	if h24, err := strconv.Atoi(lecture.StartTime[:2]); err == nil && h24 >= 16 {
+		afternoon = true
+	}
+
+	convenient := 0
+	for _, date := range lecture.SessionDates {
+		t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			continue
+		}
+
+		if afternoon == true || t.Weekday() == time.Saturday || t.Weekday() == time.Sunday || holidays.IsHoliday(t) == true {
+			convenient++
+		}
+	}
+
+	return float64(convenient) / float64(len(lecture.SessionDates)), true
+}
@@ -0,0 +1,116 @@
+package scrape
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/holiday"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"reflect"
+	"testing"
+)
+
+// testHolidays 2025년 추석 연휴(10월 5~8일), 개천절(10월 3일), 한글날(10월 9일)에 임시공휴일(10월 21일)이 추가된 공휴일 달력
+func testHolidays() *holiday.Calendar {
+	return holiday.NewCalendar([]holiday.Holiday{{Date: "2025-10-21", Name: "임시공휴일"}})
+}
+
+func TestExpandSessionDates(t *testing.T) {
+	tests := []struct {
+		name         string
+		lecture      lectures.Lecture
+		skipHolidays bool
+		want         []string
+	}{
+		{
+			name:    "매주 강좌횟수만큼",
+			lecture: lectures.Lecture{StartDate: "2025-09-30", Count: "4회"},
+			want:    []string{"2025-09-30", "2025-10-07", "2025-10-14", "2025-10-21"},
+		},
+		{
+			name:         "공휴일(추석 다음날, 임시공휴일) 휴강",
+			lecture:      lectures.Lecture{StartDate: "2025-09-30", Count: "4회"},
+			skipHolidays: true,
+			want:         []string{"2025-09-30", "2025-10-14", "2025-10-28", "2025-11-04"},
+		},
+		{
+			name:    "종강일 이후는 제외",
+			lecture: lectures.Lecture{StartDate: "2025-09-30", EndDate: "2025-10-21", Count: "12회"},
+			want:    []string{"2025-09-30", "2025-10-07", "2025-10-14", "2025-10-21"},
+		},
+		{
+			name:    "강좌횟수를 모르면 종강일까지",
+			lecture: lectures.Lecture{StartDate: "2025-09-30", EndDate: "2025-10-16"},
+			want:    []string{"2025-09-30", "2025-10-07", "2025-10-14"},
+		},
+		{
+			name:         "강좌횟수를 모르면 휴강일을 건너뛰고 종강일까지",
+			lecture:      lectures.Lecture{StartDate: "2025-09-30", EndDate: "2025-10-21"},
+			skipHolidays: true,
+			want:         []string{"2025-09-30", "2025-10-14"},
+		},
+		{
+			name:    "강좌횟수와 종강일을 모르면 개강일만",
+			lecture: lectures.Lecture{StartDate: "2025-09-30"},
+			want:    []string{"2025-09-30"},
+		},
+		{
+			name:    "강좌횟수가 0이면 개강일만",
+			lecture: lectures.Lecture{StartDate: "2025-09-30", Count: "0회"},
+			want:    []string{"2025-09-30"},
+		},
+		{
+			name:    "개강일을 모르면 계산하지 않음",
+			lecture: lectures.Lecture{StartDate: "미정", Count: "4회"},
+			want:    nil,
+		},
+	}
+
+	holidays := testHolidays()
+	for _, tt := range tests {
+		if got := expandSessionDates(&tt.lecture, holidays, tt.skipHolidays); reflect.DeepEqual(got, tt.want) == false {
+			t.Errorf("%s: expandSessionDates() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConvenientSessionRatio(t *testing.T) {
+	tests := []struct {
+		name      string
+		lecture   lectures.Lecture
+		want      float64
+		wantKnown bool
+	}{
+		{
+			name:      "주말, 공휴일(추석 다음날, 임시공휴일)",
+			lecture:   lectures.Lecture{StartTime: "10:00", SessionDates: []string{"2025-10-04", "2025-10-07", "2025-10-14", "2025-10-21"}},
+			want:      0.75,
+			wantKnown: true,
+		},
+		{
+			name:      "16시 이후",
+			lecture:   lectures.Lecture{StartTime: "16:00", SessionDates: []string{"2025-10-14", "2025-10-28"}},
+			want:      1,
+			wantKnown: true,
+		},
+		{
+			name:      "평일 16시 이전",
+			lecture:   lectures.Lecture{StartTime: "15:50", SessionDates: []string{"2025-10-14", "2025-10-28"}},
+			want:      0,
+			wantKnown: true,
+		},
+		{
+			name:    "강의일자 없음",
+			lecture: lectures.Lecture{StartTime: "10:00"},
+		},
+		{
+			name:    "시작시간 없음",
+			lecture: lectures.Lecture{SessionDates: []string{"2025-10-14"}},
+		},
+	}
+
+	holidays := testHolidays()
+	for _, tt := range tests {
+		got, known := convenientSessionRatio(&tt.lecture, holidays)
+		if got != tt.want || known != tt.wantKnown {
+			t.Errorf("%s: convenientSessionRatio() = (%v, %v), want (%v, %v)", tt.name, got, known, tt.want, tt.wantKnown)
+		}
+	}
+}
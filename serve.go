@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/server"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"github.com/darkkaiser/culturelecture-scrape/web"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// serveCommand 스냅샷 저장소에 저장된 강좌를 조회하는 REST API 서버 및 웹 화면을 실행한다.
+// 종료 신호(Ctrl+C)를 받을 때까지 실행된다.
+func serveCommand(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "서버 주소")
+	utils.CheckErr(fs.Parse(args))
+
+	// 서버가 스냅샷 저장소를 열고 있는 동안에는 다른 명령에서 스냅샷 저장소를 열 수 없으므로, 다시 수집한 강좌도 서버가 저장한다.
+	store, err := snapshot.Open(cfg.Snapshot.Path)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer store.Close()
+
+	srv := server.New(store, server.Options{
+		Config:       cfg,
+		SearchYear:   searchYear,
+		SearchSeason: searchSeason,
+
+		Holidays: cfg.HolidayCalendar(),
+		Learner: func() time.Time {
+			return learnerProfiles(cfg)[0].BirthDate()
+		},
+
+		Scrape: func() ([]lectures.Lecture, error) {
+			s := scrape.New(cfg)
+			if err := s.Scrape(searchYear, searchSeason); err != nil {
+				return nil, err
+			}
+			return s.Lectures(), nil
+		},
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", srv.Handler())
+	mux.Handle("/", web.Handler())
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("문화센터 강좌 서버를 시작합니다.(웹 화면:http://%s/, API 문서:http://%s/api/openapi.json)", *addr, *addr)
+
+	if err = httpServer.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) == false {
+		log.Fatalf("문화센터 강좌 서버를 실행하는 중에 오류가 발생하였습니다(%s)", err)
+	}
+
+	log.Println("문화센터 강좌 서버를 종료합니다.")
+}
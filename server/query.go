@@ -0,0 +1,169 @@
+package server
+
+import (
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/expr"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lectureQuery 강좌 목록 조회 조건
+type lectureQuery struct {
+	chain        string                   // 문화센터 체인 ID
+	storeName    string                   // 점포
+	dayOfTheWeek string                   // 요일(예:토요일 또는 토)
+	category     lectures.Category        // 활동 분류
+	status       lectures.ReceptionStatus // 접수상태
+	hasStatus    bool                     // 접수상태 조건이 지정되었는지의 여부
+	keyword      string                   // 강좌명 또는 강사명에 포함된 문자열
+	where        *expr.Expr               // 강좌가 만족해야 하는 조건식
+}
+
+func parseQuery(q url.Values) (*lectureQuery, error) {
+	query := &lectureQuery{
+		chain:        q.Get("chain"),
+		storeName:    q.Get("store"),
+		dayOfTheWeek: strings.TrimSuffix(q.Get("day"), "요일"),
+		keyword:      q.Get("q"),
+	}
+
+	if v := q.Get("category"); v != "" {
+		category, ok := lectures.ParseCategory(v)
+		if ok == false {
+			return nil, fmt.Errorf("지원하지 않는 활동 분류입니다(category:%s)", v)
+		}
+		query.category = category
+	}
+
+	if v := q.Get("status"); v != "" {
+		status, ok := lectures.ParseReceptionStatus(v)
+		if ok == false {
+			return nil, fmt.Errorf("지원하지 않는 접수상태입니다(status:%s)", v)
+		}
+		query.status, query.hasStatus = status, true
+	}
+
+	if v := q.Get("where"); v != "" {
+		e, err := expr.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("where:%s", err)
+		}
+		query.where = e
+	}
+
+	return query, nil
+}
+
+func (q *lectureQuery) match(lecture *lectures.Lecture) bool {
+	if q.chain != "" && lecture.Chain != q.chain {
+		return false
+	}
+	if q.storeName != "" && lecture.StoreName != q.storeName {
+		return false
+	}
+	if q.dayOfTheWeek != "" && strings.TrimSuffix(lecture.DayOfTheWeek, "요일") != q.dayOfTheWeek {
+		return false
+	}
+	if q.category != "" && lecture.Category != q.category {
+		return false
+	}
+	if q.hasStatus == true && lecture.Status != q.status {
+		return false
+	}
+	if q.keyword != "" && strings.Contains(lecture.Title, q.keyword) == false && strings.Contains(lecture.Teacher, q.keyword) == false {
+		return false
+	}
+	if q.where != nil && q.where.Match(lecture) == false {
+		return false
+	}
+	return true
+}
+
+// 정렬 가능한 항목별 비교 함수
+var lectureSortKeys = map[string]func(a, b *lectures.Lecture) int{
+	"title":     func(a, b *lectures.Lecture) int { return strings.Compare(a.Title, b.Title) },
+	"store":     func(a, b *lectures.Lecture) int { return strings.Compare(a.StoreName, b.StoreName) },
+	"startDate": func(a, b *lectures.Lecture) int { return strings.Compare(a.StartDate, b.StartDate) },
+	"startTime": func(a, b *lectures.Lecture) int { return strings.Compare(a.StartTime, b.StartTime) },
+	"day": func(a, b *lectures.Lecture) int {
+		return dayOfTheWeekIndex(a.DayOfTheWeek) - dayOfTheWeekIndex(b.DayOfTheWeek)
+	},
+	"price":  func(a, b *lectures.Lecture) int { return priceValue(a.Price) - priceValue(b.Price) },
+	"status": func(a, b *lectures.Lecture) int { return int(a.Status) - int(b.Status) },
+	"distance": func(a, b *lectures.Lecture) int {
+		return compareDistance(a.Distance, b.Distance)
+	},
+}
+
+// sortLectures 강좌 목록을 정렬한다.
+// 정렬 조건은 쉼표로 구분된 항목 목록이며, 항목 앞에 '-'를 붙이면 내림차순으로 정렬한다(예:day,startTime,-price).
+func sortLectures(lectureList []lectures.Lecture, sortParam string) error {
+	if sortParam == "" {
+		return nil
+	}
+
+	type sortKey struct {
+		compare func(a, b *lectures.Lecture) int
+		desc    bool
+	}
+
+	var keys []sortKey
+	for _, field := range strings.Split(sortParam, ",") {
+		desc := strings.HasPrefix(field, "-")
+		compare, exists := lectureSortKeys[strings.TrimPrefix(field, "-")]
+		if exists == false {
+			return fmt.Errorf("지원하지 않는 정렬 항목입니다(sort:%s)", field)
+		}
+		keys = append(keys, sortKey{compare: compare, desc: desc})
+	}
+
+	sort.SliceStable(lectureList, func(i, j int) bool {
+		for _, key := range keys {
+			c := key.compare(&lectureList[i], &lectureList[j])
+			if c == 0 {
+				continue
+			}
+			if key.desc == true {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	return nil
+}
+
+// dayOfTheWeekIndex 요일의 순서(월요일:0 ~ 일요일:6)를 반환한다. 알 수 없는 요일은 마지막 순서로 처리한다.
+func dayOfTheWeekIndex(dayOfTheWeek string) int {
+	for i, day := range []string{"월", "화", "수", "목", "금", "토", "일"} {
+		if strings.HasPrefix(dayOfTheWeek, day) == true {
+			return i
+		}
+	}
+	return 7
+}
+
+// compareDistance 거리를 비교한다. 거리를 알 수 없는(0) 강좌는 가장 먼 강좌로 취급한다.
+func compareDistance(a, b float64) int {
+	switch {
+	case a == b:
+		return 0
+	case a == 0:
+		return 1
+	case b == 0 || a < b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// priceValue 수강료 문자열(예:30,000원)을 숫자로 변환한다. 변환할 수 없으면 0을 반환한다.
+func priceValue(price string) int {
+	v, _ := strconv.Atoi(regexp.MustCompile(`[^0-9]`).ReplaceAllString(price, ""))
+	return v
+}
@@ -0,0 +1,527 @@
+package server
+
+import (
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/holiday"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// 페이지당 강좌 갯수
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// Options 서버 실행 옵션
+type Options struct {
+	Config       *config.Config
+	SearchYear   string // 검색년도
+	SearchSeason string // 검색시즌
+
+	Holidays *holiday.Calendar // 공휴일 달력
+	Learner  func() time.Time  // 강좌 수강자의 생년월일을 반환한다.
+
+	Scrape func() ([]lectures.Lecture, error) // 강좌를 다시 수집한다.
+}
+
+// Server 스냅샷 저장소에 저장된 강좌를 조회하는 REST API 서버
+type Server struct {
+	store   *snapshot.Store
+	options Options
+
+	mu            sync.Mutex
+	scraping      bool      // 강좌를 다시 수집하고 있는지의 여부
+	lastScrapedAt time.Time // 마지막으로 다시 수집한 시각
+	lastRunID     uint64    // 마지막으로 다시 수집한 실행 ID
+	lastError     string    // 마지막 재수집이 실패한 경우의 오류 메시지
+}
+
+func New(store *snapshot.Store, options Options) *Server {
+	return &Server{
+		store:   store,
+		options: options,
+	}
+}
+
+// Handler REST API 핸들러를 반환한다.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/lectures", s.handleLectures)
+	mux.HandleFunc("GET /api/lectures/{id...}", s.handleLecture)
+	mux.HandleFunc("GET /api/stores", s.handleStores)
+	mux.HandleFunc("GET /api/filters", s.handleFilters)
+	mux.HandleFunc("GET /api/runs", s.handleRuns)
+	mux.HandleFunc("GET /api/runs/{id}", s.handleRun)
+	mux.HandleFunc("GET /api/shortlists", s.handleShortlists)
+	mux.HandleFunc("PUT /api/shortlists/{learner}/{id...}", s.handleAddToShortlist)
+	mux.HandleFunc("DELETE /api/shortlists/{learner}/{id...}", s.handleRemoveFromShortlist)
+	mux.HandleFunc("GET /api/scrape", s.handleScrapeStatus)
+	mux.HandleFunc("POST /api/scrape", s.handleScrape)
+	mux.HandleFunc("GET /api/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeBody(w, r, "application/json; charset=utf-8", openAPIDocument)
+	})
+
+	return mux
+}
+
+// lecturesPage 강좌 목록 조회 결과
+type lecturesPage struct {
+	RunID    uint64             `json:"runId"`    // 실행 ID
+	Page     int                `json:"page"`     // 페이지 번호(1부터 시작)
+	PageSize int                `json:"pageSize"` // 페이지당 강좌 갯수
+	Total    int                `json:"total"`    // 조건에 해당하는 전체 강좌 갯수
+	Items    []lectures.Lecture `json:"items"`    // 강좌 목록
+}
+
+func (s *Server) handleLectures(w http.ResponseWriter, r *http.Request) {
+	run, lectureList, ok := s.runLectures(w, r)
+	if ok == false {
+		return
+	}
+
+	q := r.URL.Query()
+
+	query, err := parseQuery(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := intParam(q.Get("page"), 1)
+	if err != nil || page < 1 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("페이지 번호가 올바르지 않습니다(page:%s)", q.Get("page")))
+		return
+	}
+	pageSize, err := intParam(q.Get("pageSize"), defaultPageSize)
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("페이지당 강좌 갯수는 1 이상 %d 이하이어야 합니다(pageSize:%s)", maxPageSize, q.Get("pageSize")))
+		return
+	}
+
+	var items []lectures.Lecture
+	for _, lecture := range lectureList {
+		if query.match(&lecture) == true {
+			items = append(items, lecture)
+		}
+	}
+	if err = sortLectures(items, q.Get("sort")); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result := lecturesPage{RunID: run.ID, Page: page, PageSize: pageSize, Total: len(items), Items: []lectures.Lecture{}}
+	if from := (page - 1) * pageSize; from < len(items) {
+		to := from + pageSize
+		if to > len(items) {
+			to = len(items)
+		}
+		result.Items = items[from:to]
+	}
+
+	writeJSON(w, r, result)
+}
+
+// handleLecture 강좌를 반환한다.
+// 필터링 규칙에 의해 제외된 강좌도 반환하며, filter 파라메터가 false가 아니면 제외 여부(scrapeExcluded) 및 제외 사유(excludedReasons)를 채운다.
+func (s *Server) handleLecture(w http.ResponseWriter, r *http.Request) {
+	_, lectureList, ok := s.loadRunLectures(w, r)
+	if ok == false {
+		return
+	}
+
+	id := r.PathValue("id")
+	for _, lecture := range lectureList {
+		if lecture.ID == id {
+			if r.URL.Query().Get("filter") != "false" {
+				evaluated, err := s.evaluateLectures(r.URL.Query(), []lectures.Lecture{lecture})
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err)
+					return
+				}
+				lecture = evaluated[0]
+			}
+
+			writeJSON(w, r, lecture)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Errorf("강좌가 존재하지 않습니다(강좌 ID:%s)", id))
+}
+
+// store 점포 정보 및 점포별 강좌 갯수
+type store struct {
+	Chain          string  `json:"chain"`          // 문화센터 체인 ID
+	StoreName      string  `json:"storeName"`      // 점포
+	Address        string  `json:"address"`        // 점포 주소
+	Phone          string  `json:"phone"`          // 점포 전화번호
+	Distance       float64 `json:"distance"`       // 집에서 점포까지의 추정 도로거리(km, 알 수 없으면 0)
+	TravelMinutes  int     `json:"travelMinutes"`  // 집에서 점포까지의 추정 이동시간(분)
+	DistanceApprox bool    `json:"distanceApprox"` // 시, 군 위치로 추정한 거리인지의 여부
+	LectureCount   int     `json:"lectureCount"`   // 강좌 갯수
+}
+
+func (s *Server) handleStores(w http.ResponseWriter, r *http.Request) {
+	_, lectureList, ok := s.runLectures(w, r)
+	if ok == false {
+		return
+	}
+
+	storeMap := make(map[string]*store)
+	for _, lecture := range lectureList {
+		st, exists := storeMap[lecture.StoreName]
+		if exists == false {
+			st = &store{
+				Chain:          lecture.Chain,
+				StoreName:      lecture.StoreName,
+				Address:        lecture.StoreAddress,
+				Phone:          lecture.StorePhone,
+				Distance:       lecture.Distance,
+				TravelMinutes:  lecture.TravelMinutes,
+				DistanceApprox: lecture.DistanceApprox,
+			}
+			storeMap[lecture.StoreName] = st
+		}
+		st.LectureCount++
+	}
+
+	stores := make([]store, 0, len(storeMap))
+	for _, st := range storeMap {
+		stores = append(stores, *st)
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].StoreName < stores[j].StoreName
+	})
+
+	writeJSON(w, r, stores)
+}
+
+// handleFilters 설정된 필터링 규칙 목록을 적용되는 순서대로 반환한다.
+func (s *Server) handleFilters(w http.ResponseWriter, r *http.Request) {
+	rules := s.options.Config.Filter.Rules
+	if rules == nil {
+		rules = []config.FilterRuleConfig{}
+	}
+
+	writeJSON(w, r, rules)
+}
+
+func (s *Server) handleRuns(w http.ResponseWriter, r *http.Request) {
+	runs, err := s.store.Runs()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if runs == nil {
+		runs = []snapshot.Run{}
+	}
+
+	writeJSON(w, r, runs)
+}
+
+func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("실행 ID가 올바르지 않습니다(실행 ID:%s)", r.PathValue("id")))
+		return
+	}
+
+	run, err := s.store.Run(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, r, run)
+}
+
+// scrapeStatus 강좌 재수집 상태
+type scrapeStatus struct {
+	Scraping      bool       `json:"scraping"`                // 강좌를 다시 수집하고 있는지의 여부
+	LastScrapedAt *time.Time `json:"lastScrapedAt,omitempty"` // 마지막으로 다시 수집한 시각
+	LastRunID     uint64     `json:"lastRunId,omitempty"`     // 마지막으로 다시 수집한 실행 ID
+	LastError     string     `json:"lastError,omitempty"`     // 마지막 재수집이 실패한 경우의 오류 메시지
+}
+
+func (s *Server) currentScrapeStatus() scrapeStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := scrapeStatus{Scraping: s.scraping, LastRunID: s.lastRunID, LastError: s.lastError}
+	if s.lastScrapedAt.IsZero() == false {
+		t := s.lastScrapedAt
+		status.LastScrapedAt = &t
+	}
+	return status
+}
+
+func (s *Server) handleScrapeStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, r, s.currentScrapeStatus())
+}
+
+// handleScrape 강좌를 다시 수집한다. 수집은 백그라운드에서 실행되며, 이미 수집중이면 409를 반환한다.
+func (s *Server) handleScrape(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	if s.scraping == true {
+		s.mu.Unlock()
+		writeError(w, http.StatusConflict, fmt.Errorf("이미 강좌를 다시 수집하고 있습니다"))
+		return
+	}
+	s.scraping = true
+	s.mu.Unlock()
+
+	go s.rescrape()
+
+	w.Header().Set("Location", "/api/scrape")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(s.currentScrapeStatus())
+}
+
+// rescrape 강좌를 다시 수집하여 스냅샷 저장소에 저장한다.
+// 수집이 실패하거나 수집 중에 패닉이 발생하면 서버를 종료하지 않고 오류 메시지를 재수집 상태에 기록한다.
+func (s *Server) rescrape() {
+	now := time.Now()
+	var run *snapshot.Run
+	var err error
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("강좌를 다시 수집하는 중에 패닉이 발생하였습니다(%v)", r)
+		}
+		if err != nil {
+			log.Printf("문화센터 강좌를 다시 수집하지 못하였습니다(%s)", err)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.scraping = false
+		if err != nil {
+			s.lastError = err.Error()
+			return
+		}
+		s.lastScrapedAt = now
+		s.lastRunID = run.ID
+		s.lastError = ""
+	}()
+
+	lectureList, err := s.options.Scrape()
+	if err != nil {
+		return
+	}
+
+	run = &snapshot.Run{Time: now, SearchYear: s.options.SearchYear, SearchSeason: s.options.SearchSeason}
+	if err = s.store.Save(run, lectureList); err != nil {
+		err = fmt.Errorf("다시 수집된 문화센터 강좌 자료를 스냅샷 저장소에 저장하는 중에 오류가 발생하였습니다(%s)", err)
+		return
+	}
+
+	log.Printf("다시 수집된 문화센터 강좌 자료(%d건)를 스냅샷 저장소에 저장하였습니다.(실행 ID:%d)", run.LectureCount, run.ID)
+}
+
+// runLectures 요청된 실행(run 파라메터, 지정하지 않으면 가장 최근의 실행)의 강좌 목록을 반환한다.
+// filter 파라메터가 false가 아니면 필터링 규칙을 적용하여 제외된 강좌를 반환하지 않는다(filterLectures 참고).
+func (s *Server) runLectures(w http.ResponseWriter, r *http.Request) (*snapshot.Run, []lectures.Lecture, bool) {
+	run, lectureList, ok := s.loadRunLectures(w, r)
+	if ok == false {
+		return nil, nil, false
+	}
+
+	if r.URL.Query().Get("filter") != "false" {
+		filtered, err := s.filterLectures(r.URL.Query(), lectureList)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return nil, nil, false
+		}
+		lectureList = filtered
+	}
+
+	return run, lectureList, true
+}
+
+// loadRunLectures 요청된 실행(run 파라메터, 지정하지 않으면 가장 최근의 실행)의 강좌 목록을 필터링 규칙을 적용하지 않고 반환한다.
+func (s *Server) loadRunLectures(w http.ResponseWriter, r *http.Request) (*snapshot.Run, []lectures.Lecture, bool) {
+	var run *snapshot.Run
+	var err error
+
+	if v := r.URL.Query().Get("run"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("실행 ID가 올바르지 않습니다(run:%s)", v))
+			return nil, nil, false
+		}
+		if run, err = s.store.Run(id); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return nil, nil, false
+		}
+	} else {
+		if run, err = s.store.LatestRun(); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return nil, nil, false
+		}
+		if run == nil {
+			writeError(w, http.StatusNotFound, fmt.Errorf("스냅샷 저장소에 저장된 실행이 없습니다"))
+			return nil, nil, false
+		}
+	}
+
+	lectureList, err := s.store.Lectures(run.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+
+	// 활동 분류 및 수강료 항목이 추가되기 전에 저장된 강좌는 조회할 때 분류하고 수강료를 계산하며, 거리는 현재 설정된 집 위치로 다시 계산한다.
+	for i := range lectureList {
+		if lectureList[i].Category == "" {
+			lectureList[i].Category = scrape.Classify(&lectureList[i], s.options.Config.Chains[lectureList[i].Chain])
+		}
+		lectureList[i].NormalizePrice()
+		scrape.Locate(s.options.Config.Geo, &lectureList[i])
+	}
+
+	return run, lectureList, true
+}
+
+// filterLectures 필터링 규칙을 적용하여 제외되지 않은 강좌만 반환한다.
+func (s *Server) filterLectures(q url.Values, lectureList []lectures.Lecture) ([]lectures.Lecture, error) {
+	evaluated, err := s.evaluateLectures(q, lectureList)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []lectures.Lecture
+	for _, lecture := range evaluated {
+		if lecture.ScrapeExcluded == false {
+			filtered = append(filtered, lecture)
+		}
+	}
+
+	return filtered, nil
+}
+
+// evaluateLectures 필터링 규칙을 적용하여 제외 여부 및 제외 사유가 채워진 강좌 목록을 반환한다.
+// rules 파라메터로 적용할 필터링 규칙 이름 목록(예:closed,age)을, birthday 파라메터로 수강자의 생년월일(YYYY-MM-DD)을 지정할 수 있다.
+func (s *Server) evaluateLectures(q url.Values, lectureList []lectures.Lecture) ([]lectures.Lecture, error) {
+	sc := scrape.New(s.options.Config)
+
+	var rules []string
+	if q.Has("rules") == true {
+		rules = []string{}
+		for _, rule := range strings.Split(q.Get("rules"), ",") {
+			if rule == "" {
+				continue
+			}
+			if utils.Contains(sc.FilterRuleNames(), rule) == false {
+				return nil, fmt.Errorf("지원하지 않는 필터링 규칙입니다(rules:%s)", rule)
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	birthDate := s.options.Learner()
+	if q.Get("birthday") != "" {
+		var err error
+		if birthDate, err = time.ParseInLocation("2006-01-02", q.Get("birthday"), time.Local); err != nil {
+			return nil, fmt.Errorf("생년월일이 올바르지 않습니다(birthday:%s)", q.Get("birthday"))
+		}
+	}
+
+	sc.SetLectures(lectureList)
+	sc.FilterWith(birthDate, s.options.Holidays, rules)
+
+	return sc.Lectures(), nil
+}
+
+func (s *Server) handleShortlists(w http.ResponseWriter, r *http.Request) {
+	shortlists, err := s.store.Shortlists()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, r, shortlists)
+}
+
+func (s *Server) handleAddToShortlist(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.AddToShortlist(r.PathValue("learner"), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleRemoveFromShortlist(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.RemoveFromShortlist(r.PathValue("learner"), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON 응답을 JSON으로 출력한다.
+func writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeBody(w, r, "application/json; charset=utf-8", data)
+}
+
+// writeBody 응답 본문의 ETag를 계산하여, 요청의 If-None-Match와 같으면 304를 반환한다.
+func writeBody(w http.ResponseWriter, r *http.Request, contentType string, data []byte) {
+	hash := sha1.Sum(data)
+	etag := `"` + hex.EncodeToString(hash[:]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if match := r.Header.Get("If-None-Match"); match != "" && (match == etag || match == "*") {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
+}
+
+// apiError 오류 응답
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(apiError{Error: err.Error()})
+}
+
+func intParam(v string, defaultValue int) (int, error) {
+	if v == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(v)
+}
@@ -0,0 +1,376 @@
+package snapshot
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	bolt "go.etcd.io/bbolt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	runsBucketName       = []byte("runs")       // 수집 실행 정보(키:실행 ID)
+	lecturesBucketName   = []byte("lectures")   // 수집 실행별 강좌 목록(키:실행 ID > 강좌 ID)
+	shortlistsBucketName = []byte("shortlists") // 수강자별 관심 강좌 ID 목록(키:수강자)
+	notifiedBucketName   = []byte("notified")   // 알림 채널로 전달한 알림의 전달 시각(키:알림 키)
+)
+
+// Run 강좌 수집 실행 정보
+type Run struct {
+	ID           uint64    `json:"id"`               // 실행 ID
+	Time         time.Time `json:"time"`             // 수집 시각
+	SearchYear   string    `json:"searchYear"`       // 검색년도
+	SearchSeason string    `json:"searchSeason"`     // 검색시즌
+	Chains       []string  `json:"chains,omitempty"` // 수집한 체인 ID 목록(감시 명령처럼 일부 체인만 수집한 실행, 전체 체인을 수집한 실행은 비어 있음)
+	LectureCount int       `json:"lectureCount"`     // 수집된 강좌 갯수
+}
+
+// Partial 일부 체인만 수집한 실행인지의 여부를 반환한다.
+func (r *Run) Partial() bool {
+	return len(r.Chains) > 0
+}
+
+// Store 강좌 수집 결과를 실행별로 보관하는 스냅샷 저장소
+type Store struct {
+	db *bolt.DB
+}
+
+// Open 스냅샷 저장소 파일을 연다. 파일이 존재하지 않으면 새로 생성한다.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("스냅샷 저장소(%s)를 열 수 없습니다(%s)", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{runsBucketName, lecturesBucketName, shortlistsBucketName, notifiedBucketName} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save 수집된 강좌 목록을 새로운 실행으로 저장하고, 저장된 실행의 ID를 run에 채운다.
+func (s *Store) Save(run *Run, lectureList []lectures.Lecture) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		runs := tx.Bucket(runsBucketName)
+
+		id, err := runs.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		run.ID = id
+		run.LectureCount = len(lectureList)
+
+		data, err := json.Marshal(run)
+		if err != nil {
+			return err
+		}
+		if err = runs.Put(itob(id), data); err != nil {
+			return err
+		}
+
+		b, err := tx.Bucket(lecturesBucketName).CreateBucket(itob(id))
+		if err != nil {
+			return err
+		}
+
+		for _, lecture := range lectureList {
+			data, err := json.Marshal(lecture)
+			if err != nil {
+				return err
+			}
+			if err = b.Put([]byte(lectures.Key(&lecture)), data); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Runs 저장된 실행 목록을 오래된 순서로 반환한다.
+func (s *Store) Runs() ([]Run, error) {
+	var runs []Run
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucketName).ForEach(func(k, v []byte) error {
+			var run Run
+			if err := json.Unmarshal(v, &run); err != nil {
+				return err
+			}
+			runs = append(runs, run)
+			return nil
+		})
+	})
+
+	return runs, err
+}
+
+// FullRuns 전체 체인을 수집한 실행 목록을 오래된 순서로 반환한다.
+// 일부 체인만 수집한 실행은 다른 체인의 강좌가 없으므로 전체 수집 결과와 비교할 수 없어 제외한다.
+func (s *Store) FullRuns() ([]Run, error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return nil, err
+	}
+
+	var fullRuns []Run
+	for _, run := range runs {
+		if run.Partial() == false {
+			fullRuns = append(fullRuns, run)
+		}
+	}
+
+	return fullRuns, nil
+}
+
+// Run 실행 ID에 해당하는 실행 정보를 반환한다.
+func (s *Store) Run(id uint64) (*Run, error) {
+	var run *Run
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(runsBucketName).Get(itob(id))
+		if data == nil {
+			return fmt.Errorf("스냅샷 저장소에 실행 ID(%d)가 존재하지 않습니다", id)
+		}
+
+		run = &Run{}
+		return json.Unmarshal(data, run)
+	})
+
+	return run, err
+}
+
+// LatestRun 전체 체인을 수집한 실행 중에서 가장 최근에 저장된 실행 정보를 반환한다. 저장된 실행이 없으면 nil을 반환한다.
+func (s *Store) LatestRun() (*Run, error) {
+	var run *Run
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(runsBucketName).Cursor()
+		for _, data := c.Last(); data != nil; _, data = c.Prev() {
+			var r Run
+			if err := json.Unmarshal(data, &r); err != nil {
+				return err
+			}
+			if r.Partial() == false {
+				run = &r
+				return nil
+			}
+		}
+		return nil
+	})
+
+	return run, err
+}
+
+// Lectures 실행 ID에 해당하는 실행에서 수집된 강좌 목록을 반환한다.
+func (s *Store) Lectures(runID uint64) ([]lectures.Lecture, error) {
+	var lectureList []lectures.Lecture
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(lecturesBucketName).Bucket(itob(runID))
+		if b == nil {
+			return fmt.Errorf("스냅샷 저장소에 실행 ID(%d)의 강좌 목록이 존재하지 않습니다", runID)
+		}
+
+		return b.ForEach(func(k, v []byte) error {
+			var lecture lectures.Lecture
+			if err := json.Unmarshal(v, &lecture); err != nil {
+				return err
+			}
+			lectureList = append(lectureList, lecture)
+			return nil
+		})
+	})
+
+	return lectureList, err
+}
+
+// Query 수집 이력 조회 조건
+type Query struct {
+	SearchYear   string // 검색년도(빈 문자열이면 전체)
+	SearchSeason string // 검색시즌(빈 문자열이면 전체)
+	StoreName    string // 점포(예:롯데마트 여수점, 빈 문자열이면 전체)
+	Title        string // 강좌명에 포함된 문자열(빈 문자열이면 전체)
+}
+
+// Record 수집 이력 조회 결과
+type Record struct {
+	Lecture    lectures.Lecture // 가장 최근에 수집된 강좌 정보
+	FirstRunID uint64           // 처음 수집된 실행 ID
+	LastRunID  uint64           // 마지막으로 수집된 실행 ID
+	FirstSeen  time.Time        // 처음 수집된 시각
+	LastSeen   time.Time        // 마지막으로 수집된 시각
+}
+
+// History 조회 조건에 해당하는 실행들에서 수집된 강좌를 강좌별로 하나씩 반환한다.
+func (s *Store) History(q Query) ([]Record, error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	records := make(map[string]*Record)
+	for _, run := range runs {
+		if q.SearchYear != "" && run.SearchYear != q.SearchYear {
+			continue
+		}
+		if q.SearchSeason != "" && run.SearchSeason != q.SearchSeason {
+			continue
+		}
+
+		lectureList, err := s.Lectures(run.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lecture := range lectureList {
+			if q.StoreName != "" && lecture.StoreName != q.StoreName {
+				continue
+			}
+			if q.Title != "" && strings.Contains(lecture.Title, q.Title) == false {
+				continue
+			}
+
+			key := lectures.Key(&lecture)
+			record, exists := records[key]
+			if exists == false {
+				record = &Record{FirstRunID: run.ID, FirstSeen: run.Time}
+				records[key] = record
+				keys = append(keys, key)
+			}
+			record.Lecture = lecture
+			record.LastRunID = run.ID
+			record.LastSeen = run.Time
+		}
+	}
+
+	sort.Strings(keys)
+
+	var result []Record
+	for _, key := range keys {
+		result = append(result, *records[key])
+	}
+
+	return result, nil
+}
+
+// Shortlists 수강자별 관심 강좌 ID 목록을 반환한다.
+func (s *Store) Shortlists() (map[string][]string, error) {
+	shortlists := make(map[string][]string)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(shortlistsBucketName).ForEach(func(k, v []byte) error {
+			var ids []string
+			if err := json.Unmarshal(v, &ids); err != nil {
+				return err
+			}
+			shortlists[string(k)] = ids
+			return nil
+		})
+	})
+
+	return shortlists, err
+}
+
+// AddToShortlist 수강자의 관심 강좌 목록에 강좌를 추가한다.
+func (s *Store) AddToShortlist(learner string, id string) error {
+	return s.updateShortlist(learner, func(ids []string) []string {
+		for _, v := range ids {
+			if v == id {
+				return ids
+			}
+		}
+		return append(ids, id)
+	})
+}
+
+// RemoveFromShortlist 수강자의 관심 강좌 목록에서 강좌를 삭제한다.
+func (s *Store) RemoveFromShortlist(learner string, id string) error {
+	return s.updateShortlist(learner, func(ids []string) []string {
+		var result []string
+		for _, v := range ids {
+			if v != id {
+				result = append(result, v)
+			}
+		}
+		return result
+	})
+}
+
+func (s *Store) updateShortlist(learner string, update func(ids []string) []string) error {
+	if learner == "" {
+		return fmt.Errorf("수강자는 빈 문자열을 허용하지 않습니다")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(shortlistsBucketName)
+
+		var ids []string
+		if data := b.Get([]byte(learner)); data != nil {
+			if err := json.Unmarshal(data, &ids); err != nil {
+				return err
+			}
+		}
+
+		ids = update(ids)
+		if len(ids) == 0 {
+			return b.Delete([]byte(learner))
+		}
+
+		data, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(learner), data)
+	})
+}
+
+// Notified 알림 키에 해당하는 알림을 이미 알림 채널로 전달하였는지의 여부를 반환한다.
+func (s *Store) Notified(key string) (bool, error) {
+	notified := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		notified = tx.Bucket(notifiedBucketName).Get([]byte(key)) != nil
+		return nil
+	})
+
+	return notified, err
+}
+
+// MarkNotified 알림 키에 해당하는 알림을 알림 채널로 전달하였음을 기록한다.
+func (s *Store) MarkNotified(keys []string, t time.Time) error {
+	data, err := t.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(notifiedBucketName)
+		for _, key := range keys {
+			if err := b.Put([]byte(key), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// itob 실행 ID를 정렬 가능한 8바이트 키로 변환한다.
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
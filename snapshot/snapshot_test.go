@@ -0,0 +1,266 @@
+package snapshot
+
+import (
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// openTestStore 임시 디렉토리에 스냅샷 저장소를 생성한다.
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	s, err := Open(filepath.Join(t.TempDir(), "snapshot.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+// save 실행을 저장하고 저장된 실행을 반환한다.
+func save(t *testing.T, s *Store, run Run, lectureList []lectures.Lecture) Run {
+	t.Helper()
+
+	if err := s.Save(&run, lectureList); err != nil {
+		t.Fatal(err)
+	}
+	return run
+}
+
+func runIDs(runs []Run) []uint64 {
+	var ids []uint64
+	for _, run := range runs {
+		ids = append(ids, run.ID)
+	}
+	return ids
+}
+
+func TestSaveAndRuns(t *testing.T) {
+	s := openTestStore(t)
+
+	if run, err := s.LatestRun(); err != nil || run != nil {
+		t.Fatalf("빈 저장소의 LatestRun() = %v, %v, want nil", run, err)
+	}
+
+	t1 := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	run1 := save(t, s, Run{Time: t1, SearchYear: "2025", SearchSeason: "봄"}, []lectures.Lecture{
+		{ID: "emart/560/1", Title: "유아 발레", Status: lectures.ReceptionStatusPossible},
+		{ID: "emart/560/2", Title: "창의 미술"},
+	})
+	if run1.ID != 1 || run1.LectureCount != 2 {
+		t.Errorf("Save() run = %+v, want ID 1, LectureCount 2", run1)
+	}
+
+	run2 := save(t, s, Run{Time: t1.Add(time.Hour), SearchYear: "2025", SearchSeason: "봄"}, []lectures.Lecture{{ID: "emart/560/1", Title: "유아 발레"}})
+	partial := save(t, s, Run{Time: t1.Add(2 * time.Hour), SearchYear: "2025", SearchSeason: "봄", Chains: []string{"emart"}}, []lectures.Lecture{{ID: "emart/560/1"}})
+
+	runs, err := s.Runs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(runIDs(runs), []uint64{run1.ID, run2.ID, partial.ID}) == false {
+		t.Errorf("Runs() = %v, want [1 2 3]", runIDs(runs))
+	}
+	if runs[2].Partial() == false || reflect.DeepEqual(runs[2].Chains, []string{"emart"}) == false {
+		t.Errorf("일부 체인만 수집한 실행 = %+v", runs[2])
+	}
+	if runs[0].Time.Equal(t1) == false || runs[0].SearchSeason != "봄" {
+		t.Errorf("Runs()[0] = %+v", runs[0])
+	}
+
+	// 일부 체인만 수집한 실행은 전체 실행 목록 및 가장 최근의 실행에서 제외된다.
+	fullRuns, err := s.FullRuns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(runIDs(fullRuns), []uint64{run1.ID, run2.ID}) == false {
+		t.Errorf("FullRuns() = %v, want [1 2]", runIDs(fullRuns))
+	}
+
+	latest, err := s.LatestRun()
+	if err != nil || latest == nil || latest.ID != run2.ID {
+		t.Errorf("LatestRun() = %+v, %v, want 실행 ID 2", latest, err)
+	}
+
+	run, err := s.Run(partial.ID)
+	if err != nil || run.ID != partial.ID {
+		t.Errorf("Run(%d) = %+v, %v", partial.ID, run, err)
+	}
+	if _, err = s.Run(99); err == nil {
+		t.Errorf("존재하지 않는 실행의 Run() error = nil")
+	}
+}
+
+func TestLectures(t *testing.T) {
+	s := openTestStore(t)
+
+	lectureList := []lectures.Lecture{
+		{ID: "emart/560/2", Title: "창의 미술", Status: lectures.ReceptionStatusClosed},
+		{ID: "emart/560/1", Title: "유아 발레", Status: lectures.ReceptionStatusPossible},
+		// 강좌 ID가 없는 이전 수집 결과의 강좌
+		{Chain: "lottemart", StoreName: "롯데마트 여수점", DetailPageUrl: "http://example.com/3", Title: "아기 체육"},
+	}
+	run := save(t, s, Run{Time: time.Now(), SearchYear: "2025", SearchSeason: "봄"}, lectureList)
+
+	got, err := s.Lectures(run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 강좌는 강좌를 구분하는 키 순서로 반환된다.
+	want := []lectures.Lecture{lectureList[1], lectureList[0], lectureList[2]}
+	if reflect.DeepEqual(got, want) == false {
+		t.Errorf("Lectures() = %+v, want %+v", got, want)
+	}
+
+	if _, err = s.Lectures(99); err == nil {
+		t.Errorf("존재하지 않는 실행의 Lectures() error = nil")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	s := openTestStore(t)
+
+	t1 := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+	t2 := t1.AddDate(0, 0, 7)
+	t3 := t1.AddDate(0, 6, 0)
+	save(t, s, Run{Time: t1, SearchYear: "2025", SearchSeason: "봄"}, []lectures.Lecture{
+		{ID: "emart/560/1", StoreName: "이마트 여수점", Title: "유아 발레", Status: lectures.ReceptionStatusPossible},
+		{ID: "emart/560/2", StoreName: "이마트 여수점", Title: "창의 미술"},
+	})
+	save(t, s, Run{Time: t2, SearchYear: "2025", SearchSeason: "봄"}, []lectures.Lecture{
+		{ID: "emart/560/1", StoreName: "이마트 여수점", Title: "유아 발레", Status: lectures.ReceptionStatusClosed},
+	})
+	save(t, s, Run{Time: t3, SearchYear: "2025", SearchSeason: "가을"}, []lectures.Lecture{
+		{ID: "lottemart/705/1", StoreName: "롯데마트 여수점", Title: "유아 발레"},
+	})
+
+	records, err := s.History(Query{SearchSeason: "봄"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("History(봄) = %d건, want 2건", len(records))
+	}
+
+	// 여러 실행에서 수집된 강좌는 가장 최근에 수집된 강좌 정보와 처음 및 마지막으로 수집된 실행을 반환한다.
+	r := records[0]
+	if r.Lecture.ID != "emart/560/1" || r.Lecture.Status != lectures.ReceptionStatusClosed {
+		t.Errorf("records[0].Lecture = %+v", r.Lecture)
+	}
+	if r.FirstRunID != 1 || r.LastRunID != 2 || r.FirstSeen.Equal(t1) == false || r.LastSeen.Equal(t2) == false {
+		t.Errorf("records[0] = %+v, want 실행 1~2", r)
+	}
+	if records[1].FirstRunID != 1 || records[1].LastRunID != 1 {
+		t.Errorf("records[1] = %+v, want 실행 1", records[1])
+	}
+
+	tests := []struct {
+		q    Query
+		want []string
+	}{
+		{Query{}, []string{"emart/560/1", "emart/560/2", "lottemart/705/1"}},
+		{Query{SearchYear: "2025", SearchSeason: "가을"}, []string{"lottemart/705/1"}},
+		{Query{StoreName: "이마트 여수점"}, []string{"emart/560/1", "emart/560/2"}},
+		{Query{Title: "발레"}, []string{"emart/560/1", "lottemart/705/1"}},
+		{Query{SearchYear: "2024"}, nil},
+	}
+	for _, tt := range tests {
+		records, err := s.History(tt.q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, r := range records {
+			ids = append(ids, r.Lecture.ID)
+		}
+		if reflect.DeepEqual(ids, tt.want) == false {
+			t.Errorf("History(%+v) = %v, want %v", tt.q, ids, tt.want)
+		}
+	}
+}
+
+func TestShortlists(t *testing.T) {
+	s := openTestStore(t)
+
+	for _, id := range []string{"emart/560/1", "emart/560/2", "emart/560/1"} {
+		if err := s.AddToShortlist("첫째", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.AddToShortlist("둘째", "lottemart/705/1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddToShortlist("", "emart/560/1"); err == nil {
+		t.Errorf("빈 수강자의 AddToShortlist() error = nil")
+	}
+
+	shortlists, err := s.Shortlists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"첫째": {"emart/560/1", "emart/560/2"}, "둘째": {"lottemart/705/1"}}
+	if reflect.DeepEqual(shortlists, want) == false {
+		t.Errorf("Shortlists() = %v, want %v", shortlists, want)
+	}
+
+	// 마지막 관심 강좌를 삭제하면 수강자의 관심 강좌 목록도 삭제된다.
+	if err = s.RemoveFromShortlist("첫째", "emart/560/1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.RemoveFromShortlist("둘째", "lottemart/705/1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.RemoveFromShortlist("셋째", "emart/560/1"); err != nil {
+		t.Fatal(err)
+	}
+
+	shortlists, err = s.Shortlists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[string][]string{"첫째": {"emart/560/2"}}
+	if reflect.DeepEqual(shortlists, want) == false {
+		t.Errorf("Shortlists() = %v, want %v", shortlists, want)
+	}
+}
+
+func TestNotified(t *testing.T) {
+	s := openTestStore(t)
+
+	notified, err := s.Notified("registerSoon/emart/560/1@2024-02-20T10:00:00+09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notified == true {
+		t.Errorf("기록 전 Notified() = true, want false")
+	}
+
+	keys := []string{"registerSoon/emart/560/1@2024-02-20T10:00:00+09:00", "registerSoon/emart/560/2@2024-02-21T10:00:00+09:00"}
+	if err = s.MarkNotified(keys, time.Date(2024, 2, 19, 12, 0, 0, 0, time.Local)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{keys[0], true},
+		{keys[1], true},
+		// 접수시작일시가 변경되면 다른 알림이다.
+		{"registerSoon/emart/560/1@2024-02-22T10:00:00+09:00", false},
+	}
+	for _, tt := range tests {
+		notified, err := s.Notified(tt.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if notified != tt.want {
+			t.Errorf("Notified(%q) = %v, want %v", tt.key, notified, tt.want)
+		}
+	}
+}
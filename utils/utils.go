@@ -14,10 +14,12 @@ func CheckErr(err error) {
 	}
 }
 
-func CheckStatusCode(res *http.Response) {
+// StatusCodeError 응답 상태코드가 200이 아니면 오류를 반환한다.
+func StatusCodeError(res *http.Response) error {
 	if res.StatusCode != 200 {
-		log.Fatalln("Request failed with Status:", res.StatusCode)
+		return fmt.Errorf("Request failed with Status: %d(%s)", res.StatusCode, res.Request.URL)
 	}
+	return nil
 }
 
 func CleanString(s string) string {
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"github.com/darkkaiser/culturelecture-scrape/config"
+	"github.com/darkkaiser/culturelecture-scrape/notify"
+	"github.com/darkkaiser/culturelecture-scrape/scrape"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"github.com/darkkaiser/culturelecture-scrape/snapshot"
+	"github.com/darkkaiser/culturelecture-scrape/utils"
+	"github.com/darkkaiser/culturelecture-scrape/watch"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"syscall"
+	"time"
+)
+
+// watchCommand 설정된 주기마다 강좌를 수집하여 감시 대상 강좌의 접수상태 변경을 알린다.
+// 종료 신호(Ctrl+C)를 받을 때까지 실행된다.
+func watchCommand(cfg *config.Config) {
+	quietHours, err := watch.ParseQuietHours(cfg.Watch.QuietHours)
+	if err != nil {
+		log.Fatalf("설정 파일(%s)의 접수상태 감시 설정이 올바르지 않습니다(%s)", config.DefaultFileName, err)
+	}
+
+	notifier, err := notify.New(cfg.Notify)
+	if err != nil {
+		log.Fatalf("설정 파일(%s)의 알림 설정이 올바르지 않습니다(%s)", config.DefaultFileName, err)
+	}
+
+	// 감시할 체인 및 점포만 수집하도록 체인별 설정을 변경한다.
+	var chains []string
+	for chain, stores := range cfg.Watch.Chains {
+		chains = append(chains, chain)
+		if len(stores) > 0 {
+			cc := cfg.Chain(chain)
+			cc.Stores = stores
+			cfg.Chains[chain] = cc
+		}
+	}
+	sort.Strings(chains)
+
+	w := &watch.Watcher{
+		Interval:   cfg.Watch.IntervalDuration(),
+		Jitter:     cfg.Watch.JitterDuration(),
+		QuietHours: quietHours,
+		Matcher:    watch.NewMatcher(cfg.Watch.Lectures, cfg.Watch.Titles),
+
+		Scrape: func() ([]lectures.Lecture, error) {
+			s := scrape.New(cfg)
+			s.SetChains(chains)
+			if err := s.Scrape(searchYear, searchSeason); err != nil {
+				return nil, err
+			}
+
+			// 감시할 체인만 수집한 실행이므로 수집한 체인 ID 목록을 함께 저장하여 전체 수집 결과와 구분한다.
+			if cfg.Snapshot.Enabled == true {
+				if err := saveSnapshot(cfg, time.Now(), chains, s.Lectures()); err != nil {
+					log.Printf("수집된 문화센터 강좌 자료를 스냅샷 저장소에 저장하지 못하였습니다(%s)", err)
+				}
+			}
+
+			return s.Lectures(), nil
+		},
+		Handle: func(events []notify.Event) {
+			if err := notifier.Notify(events); err != nil {
+				log.Printf("감시 대상 강좌의 변경 이벤트 알림이 일부 실패하였습니다(%s)", err)
+			}
+		},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("%s년 %s 문화센터 강좌의 접수상태 감시를 시작합니다.(수집 주기:%s±%s)", searchYear, searchSeason, w.Interval, w.Jitter)
+
+	w.Run(ctx, watchBaseline(cfg, chains))
+
+	log.Println("문화센터 강좌의 접수상태 감시를 종료합니다.")
+}
+
+// watchBaseline 첫번째 수집 결과와 비교할 이전 수집 결과를 스냅샷 저장소에서 읽어들인다.
+// 전체 체인을 수집한 실행 또는 같은 체인을 감시하며 수집한 실행 중에서 가장 최근의 실행을 사용하며,
+// 그 실행의 검색년도 및 검색시즌이 다르면 nil을 반환한다.
+func watchBaseline(cfg *config.Config, chains []string) []lectures.Lecture {
+	if cfg.Snapshot.Enabled == false {
+		return nil
+	}
+
+	store, err := snapshot.Open(cfg.Snapshot.Path)
+	utils.CheckErr(err)
+
+	//goland:noinspection GoUnhandledErrorResult
+	defer store.Close()
+
+	runs, err := store.Runs()
+	utils.CheckErr(err)
+
+	var run *snapshot.Run
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Partial() == false || slices.Equal(runs[i].Chains, chains) == true {
+			run = &runs[i]
+			break
+		}
+	}
+	if run == nil || run.SearchYear != searchYear || run.SearchSeason != searchSeason {
+		return nil
+	}
+
+	lectureList, err := store.Lectures(run.ID)
+	utils.CheckErr(err)
+
+	return lectureList
+}
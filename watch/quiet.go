@@ -0,0 +1,69 @@
+package watch
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// QuietHours 수집하지 않는 시간대
+// 시작시각이 종료시각보다 늦으면(예:23:00-07:00) 자정을 넘기는 시간대로 처리한다.
+type QuietHours struct {
+	enabled bool
+	start   int // 시작시각(자정부터의 분)
+	end     int // 종료시각(자정부터의 분)
+}
+
+// ParseQuietHours 'hh:mm-hh:mm' 형식의 문자열을 수집하지 않는 시간대로 변환한다. 빈 문자열이면 항상 수집한다.
+func ParseQuietHours(s string) (QuietHours, error) {
+	if s == "" {
+		return QuietHours{}, nil
+	}
+
+	m := regexp.MustCompile(`^([0-9]{1,2}):([0-9]{2})\s*-\s*([0-9]{1,2}):([0-9]{2})$`).FindStringSubmatch(s)
+	if m == nil {
+		return QuietHours{}, fmt.Errorf("수집하지 않는 시간대의 형식이 올바르지 않습니다(시간대:%s, 형식:hh:mm-hh:mm)", s)
+	}
+
+	minutes := func(hh, mm string) (int, bool) {
+		h, _ := strconv.Atoi(hh)
+		m, _ := strconv.Atoi(mm)
+		return h*60 + m, h < 24 && m < 60
+	}
+
+	start, ok1 := minutes(m[1], m[2])
+	end, ok2 := minutes(m[3], m[4])
+	if ok1 == false || ok2 == false || start == end {
+		return QuietHours{}, fmt.Errorf("수집하지 않는 시간대가 올바르지 않습니다(시간대:%s)", s)
+	}
+
+	return QuietHours{enabled: true, start: start, end: end}, nil
+}
+
+// Contains 주어진 시각이 수집하지 않는 시간대에 포함되는지의 여부를 반환한다.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.enabled == false {
+		return false
+	}
+
+	m := t.Hour()*60 + t.Minute()
+	if q.start < q.end {
+		return m >= q.start && m < q.end
+	}
+	return m >= q.start || m < q.end
+}
+
+// Until 주어진 시각부터 수집하지 않는 시간대가 끝날 때까지의 시간을 반환한다.
+func (q QuietHours) Until(t time.Time) time.Duration {
+	if q.Contains(t) == false {
+		return 0
+	}
+
+	end := time.Date(t.Year(), t.Month(), t.Day(), q.end/60, q.end%60, 0, 0, t.Location())
+	if end.After(t) == false {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return end.Sub(t)
+}
@@ -0,0 +1,148 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"github.com/darkkaiser/culturelecture-scrape/diff"
+	"github.com/darkkaiser/culturelecture-scrape/notify"
+	"github.com/darkkaiser/culturelecture-scrape/scrape/lectures"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Matcher 감시 대상 강좌를 판단한다.
+// 강좌 ID 목록 및 강좌명에 포함된 문자열 목록이 모두 비어 있으면 전체 강좌가 감시 대상이다.
+type Matcher struct {
+	ids    map[string]bool
+	titles []string
+}
+
+func NewMatcher(ids []string, titles []string) *Matcher {
+	m := &Matcher{
+		ids:    make(map[string]bool),
+		titles: titles,
+	}
+	for _, id := range ids {
+		m.ids[id] = true
+	}
+	return m
+}
+
+// Match 강좌가 감시 대상인지의 여부를 반환한다.
+func (m *Matcher) Match(lecture *lectures.Lecture) bool {
+	if len(m.ids) == 0 && len(m.titles) == 0 {
+		return true
+	}
+	if m.ids[lecture.ID] == true {
+		return true
+	}
+	for _, title := range m.titles {
+		if strings.Contains(lecture.Title, title) == true {
+			return true
+		}
+	}
+	return false
+}
+
+// Events 이전 수집 결과와 이번 수집 결과를 비교하여 감시 대상 강좌의 변경 이벤트를 알림 이벤트로 반환한다.
+// 새로 수집된 강좌의 변경전 접수상태는 알수없음이다.
+func Events(now time.Time, oldLectures, newLectures []lectures.Lecture, m *Matcher) []notify.Event {
+	var events []notify.Event
+	for _, c := range diff.Compare(oldLectures, newLectures) {
+		if c.New == nil || m.Match(c.New) == false {
+			continue
+		}
+
+		switch c.Type {
+		case diff.ChangeAdded:
+			events = append(events, notify.Event{Type: notify.EventAdded, Time: now, Lecture: *c.New, OldStatus: lectures.ReceptionStatusUnknown, NewStatus: c.New.Status})
+		case diff.ChangeChanged:
+			if c.Old.Status != c.New.Status {
+				events = append(events, notify.Event{Type: notify.EventStatusChanged, Time: now, Lecture: *c.New, OldStatus: c.Old.Status, NewStatus: c.New.Status})
+			}
+		}
+	}
+	return events
+}
+
+// Watcher 주기적으로 강좌를 수집하여 감시 대상 강좌의 변경 이벤트를 전달한다.
+type Watcher struct {
+	Interval   time.Duration // 수집 주기
+	Jitter     time.Duration // 수집 주기에 임의로 더하거나 빼는 최대 시간
+	QuietHours QuietHours    // 수집하지 않는 시간대
+	Matcher    *Matcher      // 감시 대상 강좌
+
+	Scrape func() ([]lectures.Lecture, error) // 강좌를 수집한다.
+	Handle func(events []notify.Event)        // 변경 이벤트를 전달받는다.
+}
+
+// Run 컨텍스트가 취소될 때까지 강좌를 주기적으로 수집한다.
+// baseline은 첫번째 수집 결과와 비교할 이전 수집 결과이며, nil이면 첫번째 수집 결과는 비교하지 않는다.
+func (w *Watcher) Run(ctx context.Context, baseline []lectures.Lecture) {
+	previous := baseline
+	for {
+		now := time.Now()
+		if w.QuietHours.Contains(now) == true {
+			d := w.QuietHours.Until(now)
+			log.Printf("수집하지 않는 시간대입니다. %s 후에 다시 수집합니다.", d.Round(time.Second))
+			if sleep(ctx, d) == false {
+				return
+			}
+			continue
+		}
+
+		// 문화센터 사이트의 일시적인 오류로 수집이 실패하면 이번 수집은 건너뛰고, 이전 수집 결과를 다음 수집 결과와 비교한다.
+		if current, err := w.scrape(); err != nil {
+			log.Printf("강좌 수집이 실패하여 이번 수집을 건너뜁니다(%s)", err)
+		} else {
+			if previous != nil {
+				events := Events(time.Now(), previous, current, w.Matcher)
+				log.Printf("감시 대상 강좌의 변경 이벤트가 %d건 발생하였습니다.", len(events))
+				if len(events) > 0 {
+					w.Handle(events)
+				}
+			}
+			previous = current
+		}
+
+		d := w.nextDelay()
+		log.Printf("%s 후에 다시 수집합니다.", d.Round(time.Second))
+		if sleep(ctx, d) == false {
+			return
+		}
+	}
+}
+
+// scrape 강좌를 수집한다. 수집 중에 패닉이 발생하면 감시를 종료하지 않고 오류로 반환한다.
+func (w *Watcher) scrape() (lectureList []lectures.Lecture, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("강좌 수집 중에 패닉이 발생하였습니다(%v)", r)
+		}
+	}()
+
+	return w.Scrape()
+}
+
+// nextDelay 수집 주기에 임의의 편차를 더한 다음 수집까지의 대기시간을 반환한다.
+func (w *Watcher) nextDelay() time.Duration {
+	if w.Jitter <= 0 {
+		return w.Interval
+	}
+	return w.Interval + time.Duration(rand.Int63n(int64(2*w.Jitter)+1)) - w.Jitter
+}
+
+// sleep 주어진 시간동안 대기한다. 대기중에 컨텍스트가 취소되면 false를 반환한다.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,316 @@
+'use strict';
+
+// 강좌 조회 웹 화면
+// serve 명령의 REST API(/api/...)를 사용하며, 수강자 이름/생년월일은 브라우저(localStorage)에 저장한다.
+
+const PAGE_SIZE = 50;
+const DAYS = ['월', '화', '수', '목', '금', '토', '일'];
+
+const state = {
+  page: 1,
+  total: 0,
+  shortlists: {},
+  lectureCache: {},
+};
+
+const $ = (id) => document.getElementById(id);
+
+function showError(err) {
+  const el = $('error');
+  el.textContent = String(err);
+  el.hidden = false;
+  setTimeout(() => { el.hidden = true; }, 5000);
+}
+
+async function api(method, path) {
+  const res = await fetch(path, { method });
+  if (res.status === 204) {
+    return null;
+  }
+  const body = await res.json();
+  if (!res.ok) {
+    throw new Error(body.error || res.statusText);
+  }
+  return body;
+}
+
+function learner() {
+  return $('learner').value.trim();
+}
+
+// filterParams 필터링 규칙 및 수강자 생년월일 조건을 반환한다.
+function filterParams() {
+  const params = new URLSearchParams();
+  if (!$('filter').checked) {
+    params.set('filter', 'false');
+    return params;
+  }
+
+  const rules = [...document.querySelectorAll('#rules input:checked')].map((el) => el.value);
+  params.set('rules', rules.join(','));
+  if ($('birthday').value !== '') {
+    params.set('birthday', $('birthday').value);
+  }
+  return params;
+}
+
+function lectureParams() {
+  const params = filterParams();
+  for (const id of ['store', 'day', 'status', 'category', 'q', 'where', 'sort']) {
+    if ($(id).value !== '') {
+      params.set(id, $(id).value);
+    }
+  }
+  params.set('page', state.page);
+  params.set('pageSize', PAGE_SIZE);
+  return params;
+}
+
+function isStarred(id) {
+  return (state.shortlists[learner()] || []).includes(id);
+}
+
+function cell(text, className) {
+  const td = document.createElement('td');
+  td.textContent = text;
+  if (className) {
+    td.className = className;
+  }
+  return td;
+}
+
+async function loadLectures() {
+  try {
+    const result = await api('GET', '/api/lectures?' + lectureParams());
+    state.total = result.total;
+
+    const rows = $('lecture-rows');
+    rows.replaceChildren();
+    for (const lecture of result.items) {
+      state.lectureCache[lecture.id] = lecture;
+
+      const tr = document.createElement('tr');
+
+      const star = document.createElement('td');
+      star.className = 'star';
+      const button = document.createElement('button');
+      button.textContent = '★';
+      button.title = '관심 강좌';
+      button.classList.toggle('on', isStarred(lecture.id));
+      button.addEventListener('click', () => toggleStar(lecture.id));
+      star.appendChild(button);
+      tr.appendChild(star);
+
+      tr.appendChild(cell(lecture.distance > 0 ? `${lecture.storeName} (${lecture.distanceApprox ? '약 ' : ''}${lecture.distance}km)` : lecture.storeName));
+
+      const title = document.createElement('td');
+      const link = document.createElement('a');
+      link.href = lecture.detailPageUrl;
+      link.target = '_blank';
+      link.rel = 'noopener';
+      link.textContent = lecture.title;
+      title.appendChild(link);
+      tr.appendChild(title);
+
+      tr.appendChild(cell(lecture.dayOfTheWeek));
+      tr.appendChild(cell(`${lecture.startTime}~${lecture.endTime}`));
+      tr.appendChild(cell(lecture.startDate));
+      tr.appendChild(cell(lecture.price));
+      tr.appendChild(cell(lecture.status, 'status-' + lecture.status));
+
+      rows.appendChild(tr);
+    }
+
+    const pages = Math.max(1, Math.ceil(state.total / PAGE_SIZE));
+    $('total').textContent = `(${state.total}건)`;
+    $('page').textContent = `${state.page} / ${pages}`;
+    $('prev').disabled = state.page <= 1;
+    $('next').disabled = state.page >= pages;
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function loadStores() {
+  try {
+    const stores = await api('GET', '/api/stores?filter=false');
+    for (const store of stores) {
+      const option = document.createElement('option');
+      option.value = store.storeName;
+      option.textContent = `${store.storeName} (${store.lectureCount})`;
+      $('store').appendChild(option);
+    }
+  } catch (err) {
+    showError(err);
+  }
+}
+
+// loadFilters 설정된 필터링 규칙을 규칙별 체크박스로 표시한다.
+async function loadFilters() {
+  try {
+    const rules = await api('GET', '/api/filters');
+    for (const rule of rules) {
+      const label = document.createElement('label');
+      const input = document.createElement('input');
+      input.type = 'checkbox';
+      input.value = rule.name;
+      input.checked = !rule.disabled;
+      label.appendChild(input);
+      label.appendChild(document.createTextNode(` ${rule.name} (${rule.type})`));
+      $('rules').appendChild(label);
+    }
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function loadShortlists() {
+  try {
+    state.shortlists = await api('GET', '/api/shortlists');
+  } catch (err) {
+    showError(err);
+  }
+  await renderShortlist();
+}
+
+async function toggleStar(id) {
+  if (learner() === '') {
+    showError('관심 강좌를 저장할 수강자 이름을 입력하세요.');
+    return;
+  }
+
+  const path = `/api/shortlists/${encodeURIComponent(learner())}/${id}`;
+  try {
+    await api(isStarred(id) ? 'DELETE' : 'PUT', path);
+  } catch (err) {
+    showError(err);
+  }
+  await loadShortlists();
+  await loadLectures();
+}
+
+// shortlistLectures 관심 강좌 목록의 강좌 정보를 반환한다. 가장 최근의 실행에 없는 강좌는 제외한다.
+async function shortlistLectures() {
+  const lectures = [];
+  for (const id of state.shortlists[learner()] || []) {
+    if (!state.lectureCache[id]) {
+      try {
+        state.lectureCache[id] = await api('GET', `/api/lectures/${id}?filter=false`);
+      } catch (err) {
+        continue;
+      }
+    }
+    lectures.push(state.lectureCache[id]);
+  }
+  return lectures;
+}
+
+async function renderShortlist() {
+  $('shortlist-learner').textContent = learner() ? `(${learner()})` : '';
+
+  const lectures = await shortlistLectures();
+
+  const list = $('shortlist');
+  list.replaceChildren();
+  for (const lecture of lectures) {
+    const li = document.createElement('li');
+    li.textContent = `${lecture.storeName} | ${lecture.title} | ${lecture.dayOfTheWeek} ${lecture.startTime}~${lecture.endTime} | ${lecture.status}`;
+    list.appendChild(li);
+  }
+
+  renderTimetable(lectures);
+}
+
+// renderTimetable 관심 강좌를 요일별 시간순으로 표시하며, 같은 요일에 시간이 겹치는 강좌는 강조한다.
+function renderTimetable(lectures) {
+  const timetable = $('timetable');
+  timetable.replaceChildren();
+
+  for (const day of DAYS) {
+    const column = document.createElement('div');
+    column.className = 'day';
+
+    const title = document.createElement('h3');
+    title.textContent = day;
+    column.appendChild(title);
+
+    const slots = lectures
+      .filter((lecture) => lecture.dayOfTheWeek.startsWith(day))
+      .sort((a, b) => a.startTime.localeCompare(b.startTime));
+
+    slots.forEach((lecture, i) => {
+      const slot = document.createElement('div');
+      slot.className = 'slot';
+      const overlaps = slots.some((other, j) => j !== i && other.startTime < lecture.endTime && lecture.startTime < other.endTime);
+      slot.classList.toggle('conflict', overlaps);
+      slot.textContent = `${lecture.startTime} ${lecture.title}`;
+      slot.title = `${lecture.storeName} ${lecture.startTime}~${lecture.endTime}`;
+      column.appendChild(slot);
+    });
+
+    timetable.appendChild(column);
+  }
+}
+
+async function scrape() {
+  try {
+    await api('POST', '/api/scrape');
+    pollScrapeStatus();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function pollScrapeStatus() {
+  try {
+    const status = await api('GET', '/api/scrape');
+    $('scrape').disabled = status.scraping;
+    $('scrape-status').textContent = status.scraping ? '수집중...' : (status.lastError ? `수집 실패:${status.lastError}` : (status.lastRunId ? `최근 수집 실행 ID:${status.lastRunId}` : ''));
+    if (status.scraping) {
+      setTimeout(pollScrapeStatus, 3000);
+    } else if (status.lastRunId && !status.lastError) {
+      await loadLectures();
+    }
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function saveLearner() {
+  for (const id of ['learner', 'birthday']) {
+    localStorage.setItem('culturelecture.' + id, $(id).value);
+  }
+}
+
+function restoreLearner() {
+  for (const id of ['learner', 'birthday']) {
+    $(id).value = localStorage.getItem('culturelecture.' + id) || '';
+  }
+}
+
+function reload() {
+  state.page = 1;
+  loadLectures();
+}
+
+function init() {
+  restoreLearner();
+
+  for (const id of ['store', 'day', 'status', 'category', 'where', 'sort', 'filter']) {
+    $(id).addEventListener('change', reload);
+  }
+  $('q').addEventListener('input', reload);
+  $('rules').addEventListener('change', reload);
+  $('birthday').addEventListener('change', () => { saveLearner(); reload(); });
+  $('learner').addEventListener('change', () => { saveLearner(); renderShortlist(); loadLectures(); });
+
+  $('prev').addEventListener('click', () => { state.page--; loadLectures(); });
+  $('next').addEventListener('click', () => { state.page++; loadLectures(); });
+  $('scrape').addEventListener('click', scrape);
+
+  loadStores();
+  Promise.all([loadFilters(), loadShortlists()]).then(loadLectures);
+  pollScrapeStatus();
+}
+
+init();
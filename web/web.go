@@ -0,0 +1,21 @@
+package web
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// 웹 화면 정적 파일(외부 CDN을 사용하지 않으므로 실행파일만으로 동작한다)
+//
+//go:embed static
+var staticFiles embed.FS
+
+// Handler 강좌 조회 웹 화면의 정적 파일 핸들러를 반환한다.
+func Handler() http.Handler {
+	sub, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		panic(err)
+	}
+	return http.FileServer(http.FS(sub))
+}